@@ -0,0 +1,104 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Authority returns the AccessManager that governs the token's restricted functions.
+func (token *RebeccaCoinToken) Authority(ctx context.Context, opts ...CallOption) (common.Address, error) {
+	message, err := token.contractABI.Pack("authority")
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to pack authority message: %w", err)
+	}
+
+	callOptions := token.newCallOptions(opts)
+
+	callMsg := ethereum.CallMsg{
+		From: callOptions.from,
+		To:   &token.contractAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var authority common.Address
+	err = token.contractABI.UnpackIntoInterface(&authority, "authority", output)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to unpack authority: %w", err)
+	}
+
+	return authority, nil
+}
+
+// IsConsumingScheduledOp returns the isConsumingScheduledOp selector while a scheduled operation
+// is being consumed by the token and zero otherwise.
+func (token *RebeccaCoinToken) IsConsumingScheduledOp(ctx context.Context, opts ...CallOption) ([4]byte, error) {
+	message, err := token.contractABI.Pack("isConsumingScheduledOp")
+	if err != nil {
+		return [4]byte{}, fmt.Errorf("failed to pack isConsumingScheduledOp message: %w", err)
+	}
+
+	callOptions := token.newCallOptions(opts)
+
+	callMsg := ethereum.CallMsg{
+		From: callOptions.from,
+		To:   &token.contractAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return [4]byte{}, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var selector [4]byte
+	err = token.contractABI.UnpackIntoInterface(&selector, "isConsumingScheduledOp", output)
+	if err != nil {
+		return [4]byte{}, fmt.Errorf("failed to unpack isConsumingScheduledOp: %w", err)
+	}
+
+	return selector, nil
+}
+
+// Mint creates amount tokens and assigns them to to.
+// When the authority gives the signer a delay, the AccessManager rejects the call with *ErrAccessManagerNotScheduled,
+// *ErrAccessManagerNotReady or *ErrAccessManagerExpired unless a ready operation was scheduled; see
+// AccessManager.ExecuteRestricted. It fails with *ErrAccessManagedUnauthorized when the signer may not mint at all.
+func (token *RebeccaCoinToken) Mint(ctx context.Context, to string, amount *big.Int) (*types.Transaction, error) {
+	_to, err := ParseAddress(to)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := token.transact(ctx, "mint", _to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact mint: %w", err)
+	}
+
+	return tx, nil
+}
+
+// SetAuthority transfers control of the token's restricted functions to a new AccessManager.
+// Only the current authority may call it, so it usually has to go through AccessManager.execute.
+func (token *RebeccaCoinToken) SetAuthority(ctx context.Context, newAuthority string) (*types.Transaction, error) {
+	_newAuthority, err := ParseAddress(newAuthority)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := token.transact(ctx, "setAuthority", _newAuthority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact setAuthority: %w", err)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,475 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// schedulePollInterval is the delay between block timestamp checks while waiting for a scheduled operation.
+	schedulePollInterval = 12 * time.Second
+
+	// accessManagerABIJSONSource is the subset of the OpenZeppelin AccessManager ABI used to schedule restricted calls
+	// and decode the errors they revert with.
+	accessManagerABIJSONSource = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "caller", "type": "address"},
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bytes4", "name": "selector", "type": "bytes4"}
+		],
+		"name": "canCall",
+		"outputs": [
+			{"internalType": "bool", "name": "immediate", "type": "bool"},
+			{"internalType": "uint32", "name": "delay", "type": "uint32"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "caller", "type": "address"},
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"}
+		],
+		"name": "hashOperation",
+		"outputs": [
+			{"internalType": "bytes32", "name": "", "type": "bytes32"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "id", "type": "bytes32"}
+		],
+		"name": "getSchedule",
+		"outputs": [
+			{"internalType": "uint48", "name": "", "type": "uint48"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "id", "type": "bytes32"}
+		],
+		"name": "getNonce",
+		"outputs": [
+			{"internalType": "uint32", "name": "", "type": "uint32"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"},
+			{"internalType": "uint48", "name": "when", "type": "uint48"}
+		],
+		"name": "schedule",
+		"outputs": [
+			{"internalType": "bytes32", "name": "operationId", "type": "bytes32"},
+			{"internalType": "uint32", "name": "nonce", "type": "uint32"}
+		],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"}
+		],
+		"name": "execute",
+		"outputs": [
+			{"internalType": "uint32", "name": "", "type": "uint32"}
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "operationId", "type": "bytes32"}
+		],
+		"name": "AccessManagerAlreadyScheduled",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "operationId", "type": "bytes32"}
+		],
+		"name": "AccessManagerNotScheduled",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "operationId", "type": "bytes32"}
+		],
+		"name": "AccessManagerNotReady",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes32", "name": "operationId", "type": "bytes32"}
+		],
+		"name": "AccessManagerExpired",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "msgsender", "type": "address"},
+			{"internalType": "uint64", "name": "roleId", "type": "uint64"}
+		],
+		"name": "AccessManagerUnauthorizedAccount",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "caller", "type": "address"},
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bytes4", "name": "selector", "type": "bytes4"}
+		],
+		"name": "AccessManagerUnauthorizedCall",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{"internalType": "address", "name": "target", "type": "address"}
+		],
+		"name": "AccessManagerUnauthorizedConsume",
+		"type": "error"
+	}
+]`
+)
+
+// ErrOperationNotScheduled is returned by AccessManager.Operation when the operation has no pending schedule.
+var ErrOperationNotScheduled = errors.New("operation not scheduled")
+
+type (
+	// AccessManager schedules and executes restricted calls through an OpenZeppelin AccessManager.
+	// It signs with the signer of the token it was created from.
+	AccessManager struct {
+		token   *RebeccaCoinToken
+		address common.Address
+	}
+
+	// ScheduledOperation is a restricted call waiting on the AccessManager's delay.
+	ScheduledOperation struct {
+		ID         common.Hash
+		Nonce      uint32
+		Target     common.Address
+		Data       []byte
+		ETA        time.Time
+		ScheduleTx *types.Transaction
+	}
+)
+
+var (
+	accessManagerABIOnce   sync.Once
+	accessManagerABIParsed abi.ABI
+	accessManagerABIErr    error
+)
+
+// AccessManager returns the AccessManager currently governing the token.
+func (token *RebeccaCoinToken) AccessManager(ctx context.Context) (*AccessManager, error) {
+	authority, err := token.Authority(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessManager{
+		token:   token,
+		address: authority,
+	}, nil
+}
+
+// NewAccessManager binds the AccessManager at address to the token's client and signer.
+func (token *RebeccaCoinToken) NewAccessManager(address string) (*AccessManager, error) {
+	_address, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessManager{
+		token:   token,
+		address: _address,
+	}, nil
+}
+
+// MintThroughAuthority mints amount tokens to to, scheduling the call on the authority and waiting for
+// its delay when required. It blocks until the mint is broadcast or ctx is done.
+func (token *RebeccaCoinToken) MintThroughAuthority(ctx context.Context, to string, amount *big.Int) (*types.Transaction, error) {
+	_to, err := ParseAddress(to)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := token.contractABI.Pack("mint", _to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack mint message: %w", err)
+	}
+
+	manager, err := token.AccessManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.ExecuteRestricted(ctx, token.contractAddress, data)
+}
+
+// Address returns the address of the AccessManager.
+func (manager *AccessManager) Address() common.Address {
+	return manager.address
+}
+
+// CanCall reports whether caller may call the selector on target right away, or else the delay it must schedule with.
+func (manager *AccessManager) CanCall(ctx context.Context, caller common.Address, target common.Address, selector [4]byte) (bool, uint32, error) {
+	output, err := manager.call(ctx, "canCall", caller, target, selector)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var result struct {
+		Immediate bool
+		Delay     uint32
+	}
+	err = manager.unpack(&result, "canCall", output)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return result.Immediate, result.Delay, nil
+}
+
+// Schedule schedules the call of data on target for the earliest time the delay allows, waits for the
+// schedule transaction to be mined and returns the tracked operation.
+func (manager *AccessManager) Schedule(ctx context.Context, target common.Address, data []byte) (*ScheduledOperation, error) {
+	message, err := manager.pack("schedule", target, data, new(big.Int))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := manager.token.sendTransaction(ctx, manager.address, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact schedule: %w", err)
+	}
+
+	_, err = manager.token.WaitMined(ctx, tx.Hash(), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := manager.Operation(ctx, manager.token.signer.Address(), target, data)
+	if err != nil {
+		return nil, err
+	}
+
+	operation.ScheduleTx = tx
+
+	return operation, nil
+}
+
+// Operation returns the scheduled operation of caller calling data on target.
+// It returns ErrOperationNotScheduled when the operation has not been scheduled.
+func (manager *AccessManager) Operation(ctx context.Context, caller common.Address, target common.Address, data []byte) (*ScheduledOperation, error) {
+	output, err := manager.call(ctx, "hashOperation", caller, target, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var id common.Hash
+	err = manager.unpack(&id, "hashOperation", output)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err = manager.call(ctx, "getSchedule", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var timepoint *big.Int
+	err = manager.unpack(&timepoint, "getSchedule", output)
+	if err != nil {
+		return nil, err
+	}
+	if timepoint.Sign() == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrOperationNotScheduled, id.Hex())
+	}
+
+	output, err = manager.call(ctx, "getNonce", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce uint32
+	err = manager.unpack(&nonce, "getNonce", output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledOperation{
+		ID:     id,
+		Nonce:  nonce,
+		Target: target,
+		Data:   data,
+		ETA:    time.Unix(timepoint.Int64(), 0),
+	}, nil
+}
+
+// WaitReady blocks until the latest block timestamp reaches the operation's ETA.
+func (manager *AccessManager) WaitReady(ctx context.Context, operation *ScheduledOperation) error {
+	ticker := time.NewTicker(schedulePollInterval)
+	defer ticker.Stop()
+
+	for {
+		header, err := manager.token.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest header: %w", err)
+		}
+
+		if int64(header.Time) >= operation.ETA.Unix() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Execute runs a call through the AccessManager, consuming its scheduled operation if there is one.
+func (manager *AccessManager) Execute(ctx context.Context, target common.Address, data []byte) (*types.Transaction, error) {
+	message, err := manager.pack("execute", target, data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := manager.token.sendTransaction(ctx, manager.address, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact execute: %w", err)
+	}
+
+	return tx, nil
+}
+
+// ExecuteRestricted runs the call of data on target as the token's signer: directly when allowed,
+// otherwise by scheduling it, waiting for the delay and executing it through the AccessManager.
+func (manager *AccessManager) ExecuteRestricted(ctx context.Context, target common.Address, data []byte) (*types.Transaction, error) {
+	if manager.token.signer == nil {
+		return nil, ErrNoSigner
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("call data too short")
+	}
+
+	caller := manager.token.signer.Address()
+
+	immediate, delay, err := manager.CanCall(ctx, caller, target, [4]byte(data[:4]))
+	if err != nil {
+		return nil, err
+	}
+
+	if immediate {
+		tx, err := manager.token.sendTransaction(ctx, target, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transact restricted call: %w", err)
+		}
+
+		return tx, nil
+	}
+
+	if delay == 0 {
+		return nil, &ErrAccessManagedUnauthorized{Caller: caller}
+	}
+
+	operation, err := manager.Operation(ctx, caller, target, data)
+	if errors.Is(err, ErrOperationNotScheduled) {
+		operation, err = manager.Schedule(ctx, target, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.WaitReady(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.Execute(ctx, target, data)
+}
+
+// call runs a view method of the AccessManager against the latest block.
+func (manager *AccessManager) call(ctx context.Context, method string, args ...any) ([]byte, error) {
+	message, err := manager.pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	callMsg := ethereum.CallMsg{
+		From: manager.token.defaultFrom(),
+		To:   &manager.address,
+		Data: message,
+	}
+
+	output, err := manager.token.callContract(ctx, callMsg, manager.token.newCallOptions(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call access manager: %w", err)
+	}
+
+	return output, nil
+}
+
+// pack packs a call of an AccessManager method.
+func (manager *AccessManager) pack(method string, args ...any) ([]byte, error) {
+	managerABI, err := getAccessManagerABI()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := managerABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s message: %w", method, err)
+	}
+
+	return message, nil
+}
+
+// unpack unpacks the output of an AccessManager method.
+func (manager *AccessManager) unpack(v any, method string, output []byte) error {
+	managerABI, err := getAccessManagerABI()
+	if err != nil {
+		return err
+	}
+
+	err = managerABI.UnpackIntoInterface(v, method, output)
+	if err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+
+	return nil
+}
+
+// getAccessManagerABI parses the AccessManager ABI on first use.
+func getAccessManagerABI() (abi.ABI, error) {
+	accessManagerABIOnce.Do(func() {
+		accessManagerABIParsed, accessManagerABIErr = abi.JSON(strings.NewReader(accessManagerABIJSONSource))
+	})
+	if accessManagerABIErr != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse access manager ABI: %w", accessManagerABIErr)
+	}
+
+	return accessManagerABIParsed, nil
+}
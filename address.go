@@ -0,0 +1,66 @@
+package rebecca_coin_contract
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrZeroAddress is returned when tokens would be sent to the zero address, which burns them for good.
+// Use WithAllowZeroAddress to send to it anyway.
+var ErrZeroAddress = errors.New("refusing to send tokens to the zero address")
+
+// ErrInvalidAddress is returned for address strings that are malformed or fail their EIP-55 checksum.
+type ErrInvalidAddress struct {
+	Address string
+	Reason  string
+}
+
+func (err *ErrInvalidAddress) Error() string {
+	return fmt.Sprintf("invalid address %q: %s", err.Address, err.Reason)
+}
+
+// ParseAddress parses a hex address with an optional 0x prefix.
+// Mixed-case addresses must carry a valid EIP-55 checksum; all-lowercase and all-uppercase ones are accepted as is.
+func ParseAddress(address string) (common.Address, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+
+	if len(digits) != 2*common.AddressLength {
+		return common.Address{}, &ErrInvalidAddress{Address: address, Reason: fmt.Sprintf("expected %d hex digits, got %d", 2*common.AddressLength, len(digits))}
+	}
+	if !common.IsHexAddress(digits) {
+		return common.Address{}, &ErrInvalidAddress{Address: address, Reason: "not a hex string"}
+	}
+
+	parsed := common.HexToAddress(digits)
+	if strings.ToLower(digits) != digits && strings.ToUpper(digits) != digits && parsed.Hex()[2:] != digits {
+		return common.Address{}, &ErrInvalidAddress{Address: address, Reason: "bad EIP-55 checksum"}
+	}
+
+	return parsed, nil
+}
+
+// parseAddresses parses every address with ParseAddress.
+func parseAddresses(addresses []string) ([]common.Address, error) {
+	parsed := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		var err error
+		parsed[i], err = ParseAddress(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parsed, nil
+}
+
+// checkRecipient refuses the zero address as a recipient of tokens unless the token allows it.
+func (token *RebeccaCoinToken) checkRecipient(to common.Address) error {
+	if to == (common.Address{}) && !token.allowZeroAddress {
+		return ErrZeroAddress
+	}
+
+	return nil
+}
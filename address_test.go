@@ -0,0 +1,66 @@
+package rebecca_coin_contract
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseAddress(t *testing.T) {
+	want := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{name: "checksummed", address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "all lowercase", address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+		{name: "all uppercase", address: "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"},
+		{name: "no prefix", address: "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "uppercase prefix", address: "0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "bad checksum", address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", wantErr: true},
+		{name: "too short", address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", wantErr: true},
+		{name: "too long", address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", wantErr: true},
+		{name: "not hex", address: "0xzzaeb6053f3e94c9b9a09f33669435e7ef1beaed", wantErr: true},
+		{name: "empty", address: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseAddress(test.address)
+
+			if test.wantErr {
+				var invalid *ErrInvalidAddress
+				if !errors.As(err, &invalid) {
+					t.Fatalf("ParseAddress(%q) error = %v, want *ErrInvalidAddress", test.address, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseAddress(%q) error = %v", test.address, err)
+			}
+			if got != want {
+				t.Errorf("ParseAddress(%q) = %s, want %s", test.address, got.Hex(), want.Hex())
+			}
+		})
+	}
+}
+
+func TestCheckRecipient(t *testing.T) {
+	token := newTestToken(t)
+
+	if err := token.checkRecipient(common.Address{}); !errors.Is(err, ErrZeroAddress) {
+		t.Errorf("checkRecipient(zero) = %v, want ErrZeroAddress", err)
+	}
+	if err := token.checkRecipient(common.HexToAddress("0x01")); err != nil {
+		t.Errorf("checkRecipient(non-zero) = %v", err)
+	}
+
+	WithAllowZeroAddress()(token)
+	if err := token.checkRecipient(common.Address{}); err != nil {
+		t.Errorf("checkRecipient(zero) with WithAllowZeroAddress = %v", err)
+	}
+}
@@ -0,0 +1,631 @@
+package rebecca_coin_contract
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// defaultTrackerConfirmations is the number of blocks the tracker stays behind the latest block.
+	defaultTrackerConfirmations = 12
+
+	// defaultSpotCheckSample is the number of pairs checked against the chain by a periodic spot check.
+	defaultSpotCheckSample = 100
+
+	// allowanceJournalLimit is the number of tracked blocks an allowance tracker can roll back.
+	allowanceJournalLimit = 1024
+)
+
+type (
+	// AllowanceTrackerOpts configures an allowance tracker.
+	AllowanceTrackerOpts struct {
+		// StartBlock is the first block tracked, usually the token's deployment block.
+		StartBlock uint64
+
+		// Confirmations is the number of blocks the tracker stays behind the latest block.
+		// Zero uses defaultTrackerConfirmations.
+		Confirmations uint64
+
+		// PollInterval is the delay between syncs in Run.
+		PollInterval time.Duration
+
+		// SpotCheckInterval is the delay between spot checks in Run. Zero disables them.
+		SpotCheckInterval time.Duration
+
+		// SpotCheckSample is the number of random pairs checked by a periodic spot check.
+		SpotCheckSample int
+
+		// Backfill configures the log queries of a sync.
+		Backfill *BackfillOpts
+
+		// OnError is called with errors Run recovers from by retrying.
+		OnError func(err error)
+
+		// OnSpotCheck is called with the report of each periodic spot check.
+		OnSpotCheck func(report *SpotCheckReport)
+	}
+
+	// AllowanceTracker keeps the outstanding allowance of every owner/spender pair by replaying the
+	// token's Approval events and the Transfer events of transferFrom calls.
+	//
+	// The token does not emit Approval when transferFrom spends an allowance, so transfers made directly
+	// through transferFrom are subtracted from the tracked allowance, and the allowances of owners whose
+	// tokens are moved by other contracts are read back from the chain at the end of the block.
+	AllowanceTracker struct {
+		token    *RebeccaCoinToken
+		opts     AllowanceTrackerOpts
+		syncer   *confirmedSync
+		syncLock sync.Mutex
+
+		lock       sync.RWMutex
+		head       *BlockRef
+		allowances map[allowanceKey]*allowanceState
+		spenders   map[common.Address]map[common.Address]struct{}
+		journal    []*allowanceJournalEntry
+	}
+
+	// TrackedAllowance is the outstanding allowance of a spender over an owner's tokens.
+	TrackedAllowance struct {
+		Owner        common.Address
+		Spender      common.Address
+		Value        *big.Int
+		Unlimited    bool
+		UpdatedBlock uint64
+	}
+
+	// AllowanceMismatch is a pair whose tracked allowance differed from its on-chain allowance.
+	AllowanceMismatch struct {
+		Owner   common.Address
+		Spender common.Address
+		Tracked *big.Int
+		OnChain *big.Int
+	}
+
+	// SpotCheckReport compares tracked allowances with the chain at the tracker's head block.
+	// Mismatched allowances are replaced by their on-chain values.
+	SpotCheckReport struct {
+		Block      BlockRef
+		Checked    int
+		Mismatches []AllowanceMismatch
+	}
+
+	// allowanceKey identifies an owner/spender pair.
+	allowanceKey struct {
+		owner   common.Address
+		spender common.Address
+	}
+
+	// allowanceState is a tracked allowance and the block that last changed it.
+	allowanceState struct {
+		value *big.Int
+		block uint64
+	}
+
+	// allowanceJournalEntry records what a block changed so it can be rolled back.
+	allowanceJournalEntry struct {
+		block        BlockRef
+		previousHead *BlockRef
+		previous     map[allowanceKey]*allowanceState
+		refresh      map[common.Address]struct{}
+	}
+)
+
+// NewAllowanceTracker creates a new AllowanceTracker instance.
+func (token *RebeccaCoinToken) NewAllowanceTracker(opts *AllowanceTrackerOpts) *AllowanceTracker {
+	var trackerOpts AllowanceTrackerOpts
+	if opts != nil {
+		trackerOpts = *opts
+	}
+	if trackerOpts.Confirmations == 0 {
+		trackerOpts.Confirmations = defaultTrackerConfirmations
+	}
+	if trackerOpts.PollInterval <= 0 {
+		trackerOpts.PollInterval = defaultPollInterval
+	}
+	if trackerOpts.SpotCheckSample <= 0 {
+		trackerOpts.SpotCheckSample = defaultSpotCheckSample
+	}
+
+	tracker := &AllowanceTracker{
+		token:      token,
+		opts:       trackerOpts,
+		allowances: make(map[allowanceKey]*allowanceState),
+		spenders:   make(map[common.Address]map[common.Address]struct{}),
+	}
+	tracker.syncer = &confirmedSync{
+		token:         token,
+		state:         tracker,
+		events:        []string{"Approval", "Transfer"},
+		startBlock:    trackerOpts.StartBlock,
+		confirmations: trackerOpts.Confirmations,
+		backfill:      trackerOpts.Backfill,
+	}
+
+	return tracker
+}
+
+// Run syncs the tracker until ctx is done, spot checking a sample of pairs every SpotCheckInterval.
+func (tracker *AllowanceTracker) Run(ctx context.Context) error {
+	lastSpotCheck := time.Now()
+
+	return runWithBackoff(ctx, tracker.opts.PollInterval, tracker.opts.OnError, func(ctx context.Context) error {
+		err := tracker.Sync(ctx)
+		if err != nil || tracker.opts.SpotCheckInterval <= 0 || time.Since(lastSpotCheck) < tracker.opts.SpotCheckInterval {
+			return err
+		}
+
+		report, err := tracker.SpotCheck(ctx, tracker.samplePairs(tracker.opts.SpotCheckSample))
+		if err != nil {
+			return err
+		}
+
+		lastSpotCheck = time.Now()
+		if tracker.opts.OnSpotCheck != nil {
+			tracker.opts.OnSpotCheck(report)
+		}
+
+		return nil
+	})
+}
+
+// Sync rolls back blocks that were reorged out of the chain and replays the Approval and Transfer events
+// up to the latest block minus the confirmation depth.
+func (tracker *AllowanceTracker) Sync(ctx context.Context) error {
+	tracker.syncLock.Lock()
+	defer tracker.syncLock.Unlock()
+
+	return tracker.syncer.sync(ctx)
+}
+
+// Head returns the last tracked block, or nil if nothing was tracked yet.
+func (tracker *AllowanceTracker) Head() *BlockRef {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	if tracker.head == nil {
+		return nil
+	}
+
+	head := *tracker.head
+	return &head
+}
+
+// Allowance returns the tracked allowance of spender over owner's tokens.
+func (tracker *AllowanceTracker) Allowance(owner common.Address, spender common.Address) *big.Int {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	state, ok := tracker.allowances[allowanceKey{owner: owner, spender: spender}]
+	if !ok {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(state.value)
+}
+
+// Allowances returns the outstanding allowances over owner's tokens, ordered by spender.
+func (tracker *AllowanceTracker) Allowances(owner common.Address) []TrackedAllowance {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	allowances := make([]TrackedAllowance, 0, len(tracker.spenders[owner]))
+	for spender := range tracker.spenders[owner] {
+		allowances = append(allowances, tracker.trackedAllowance(allowanceKey{owner: owner, spender: spender}))
+	}
+
+	sortTrackedAllowances(allowances)
+
+	return allowances
+}
+
+// UnlimitedAllowances returns every unlimited allowance, ordered by owner and spender.
+func (tracker *AllowanceTracker) UnlimitedAllowances() []TrackedAllowance {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	var allowances []TrackedAllowance
+	for key, state := range tracker.allowances {
+		if isUnlimitedAllowance(state.value) {
+			allowances = append(allowances, tracker.trackedAllowance(key))
+		}
+	}
+
+	sortTrackedAllowances(allowances)
+
+	return allowances
+}
+
+// SpotCheck compares the tracked allowances of pairs with the chain at the head block and corrects mismatches.
+func (tracker *AllowanceTracker) SpotCheck(ctx context.Context, pairs []AllowancePair) (*SpotCheckReport, error) {
+	tracker.syncLock.Lock()
+	defer tracker.syncLock.Unlock()
+
+	head := tracker.Head()
+	if head == nil {
+		return nil, fmt.Errorf("nothing tracked yet")
+	}
+
+	keys := make([]allowanceKey, len(pairs))
+	for i, pair := range pairs {
+		owner, err := ParseAddress(pair.Owner)
+		if err != nil {
+			return nil, err
+		}
+
+		spender, err := ParseAddress(pair.Spender)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = allowanceKey{owner: owner, spender: spender}
+	}
+
+	results, err := tracker.token.Allowances(ctx, pairs, AtBlockHash(head.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SpotCheckReport{
+		Block:   *head,
+		Checked: len(pairs),
+	}
+
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	var entry *allowanceJournalEntry
+	if len(tracker.journal) > 0 {
+		entry = tracker.journal[len(tracker.journal)-1]
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to get allowance of %s for %s: %w", result.Owner, result.Spender, result.Err)
+		}
+
+		key := keys[i]
+
+		tracked := new(big.Int)
+		if state, ok := tracker.allowances[key]; ok {
+			tracked = state.value
+		}
+
+		if tracked.Cmp(result.Allowance) != 0 {
+			report.Mismatches = append(report.Mismatches, AllowanceMismatch{
+				Owner:   key.owner,
+				Spender: key.spender,
+				Tracked: new(big.Int).Set(tracked),
+				OnChain: result.Allowance,
+			})
+
+			tracker.set(entry, key, result.Allowance, head.Number)
+		}
+	}
+
+	return report, nil
+}
+
+// samplePairs returns up to n random tracked pairs.
+func (tracker *AllowanceTracker) samplePairs(n int) []AllowancePair {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	sample := make([]AllowancePair, 0, n)
+	seen := 0
+
+	for key := range tracker.allowances {
+		pair := AllowancePair{Owner: key.owner.Hex(), Spender: key.spender.Hex()}
+
+		seen++
+		if len(sample) < n {
+			sample = append(sample, pair)
+		} else if i := rand.Intn(seen); i < n {
+			sample[i] = pair
+		}
+	}
+
+	return sample
+}
+
+// syncHead returns the last tracked block.
+func (tracker *AllowanceTracker) syncHead() (*BlockRef, error) {
+	return tracker.Head(), nil
+}
+
+// rollbackBlock undoes the newest tracked block and returns the new head.
+func (tracker *AllowanceTracker) rollbackBlock() (*BlockRef, error) {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	if len(tracker.journal) == 0 {
+		return nil, ErrReorgTooDeep
+	}
+
+	entry := tracker.journal[len(tracker.journal)-1]
+	tracker.journal = tracker.journal[:len(tracker.journal)-1]
+
+	tracker.restore(entry)
+	tracker.head = entry.previousHead
+
+	return tracker.head, nil
+}
+
+// applyBlock replays the Approval and Transfer events of block. If any of them fails, the changes of the
+// block are undone so that the next sync replays it from scratch.
+func (tracker *AllowanceTracker) applyBlock(ctx context.Context, block BlockRef, logs []types.Log) error {
+	entry := newAllowanceJournalEntry(block)
+
+	err := tracker.applyEvents(ctx, entry, logs)
+	if err == nil {
+		err = tracker.commit(ctx, entry)
+	}
+	if err != nil {
+		tracker.lock.Lock()
+		tracker.restore(entry)
+		tracker.lock.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// applyEvents replays the Approval and Transfer events of entry's block, recording what they change in entry.
+func (tracker *AllowanceTracker) applyEvents(ctx context.Context, entry *allowanceJournalEntry, logs []types.Log) error {
+	approvalID := tracker.token.contractABI.Events["Approval"].ID
+
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		if log.Topics[0] == approvalID {
+			event, err := tracker.token.ParseApproval(log)
+			if err != nil {
+				return err
+			}
+
+			tracker.lock.Lock()
+			tracker.set(entry, allowanceKey{owner: event.Owner, spender: event.Spender}, event.Value, event.BlockNumber)
+			tracker.lock.Unlock()
+
+			continue
+		}
+
+		event, err := tracker.token.ParseTransfer(log)
+		if err != nil {
+			return err
+		}
+
+		err = tracker.applyTransfer(ctx, entry, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyTransfer spends the allowance used by a transfer of an owner with outstanding allowances.
+// Transfers sent by the owner spend nothing, direct transferFrom calls spend the caller's allowance,
+// and transfers made through other contracts schedule the owner's allowances for a refresh.
+func (tracker *AllowanceTracker) applyTransfer(ctx context.Context, entry *allowanceJournalEntry, event *TransferEvent) error {
+	if event.From == (common.Address{}) || !tracker.hasAllowances(event.From) {
+		return nil
+	}
+	if _, ok := entry.refresh[event.From]; ok {
+		return nil
+	}
+
+	tx, _, err := tracker.token.client.TransactionByHash(ctx, event.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %w", event.TxHash.Hex(), err)
+	}
+
+	sender, err := tracker.token.client.TransactionSender(ctx, tx, event.BlockHash, event.TxIndex)
+	if err != nil {
+		return fmt.Errorf("failed to get sender of transaction %s: %w", event.TxHash.Hex(), err)
+	}
+
+	if sender == event.From {
+		return nil
+	}
+
+	if tx.To() == nil || *tx.To() != tracker.token.contractAddress || !tracker.isTransferFrom(tx.Data(), event) {
+		entry.refresh[event.From] = struct{}{}
+		return nil
+	}
+
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	key := allowanceKey{owner: event.From, spender: sender}
+
+	state, ok := tracker.allowances[key]
+	if !ok || isUnlimitedAllowance(state.value) {
+		return nil
+	}
+
+	remaining := new(big.Int).Sub(state.value, event.Value)
+	if remaining.Sign() < 0 {
+		remaining.SetUint64(0)
+	}
+
+	tracker.set(entry, key, remaining, event.BlockNumber)
+
+	return nil
+}
+
+// isTransferFrom reports whether input is a transferFrom call matching the Transfer event.
+func (tracker *AllowanceTracker) isTransferFrom(input []byte, event *TransferEvent) bool {
+	method := tracker.token.contractABI.Methods["transferFrom"]
+	if len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return false
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil || len(args) != 3 {
+		return false
+	}
+
+	from, _ := args[0].(common.Address)
+	to, _ := args[1].(common.Address)
+	value, _ := args[2].(*big.Int)
+
+	return from == event.From && to == event.To && value != nil && value.Cmp(event.Value) == 0
+}
+
+// commit refreshes the allowances scheduled by entry's block and makes the block the head.
+func (tracker *AllowanceTracker) commit(ctx context.Context, entry *allowanceJournalEntry) error {
+	if len(entry.refresh) > 0 {
+		tracker.lock.RLock()
+		var (
+			keys  []allowanceKey
+			pairs []AllowancePair
+		)
+		for owner := range entry.refresh {
+			for spender := range tracker.spenders[owner] {
+				keys = append(keys, allowanceKey{owner: owner, spender: spender})
+				pairs = append(pairs, AllowancePair{Owner: owner.Hex(), Spender: spender.Hex()})
+			}
+		}
+		tracker.lock.RUnlock()
+
+		results, err := tracker.token.Allowances(ctx, pairs, AtBlockHash(entry.block.Hash))
+		if err != nil {
+			return fmt.Errorf("failed to refresh allowances at block %d: %w", entry.block.Number, err)
+		}
+
+		tracker.lock.Lock()
+		for i, result := range results {
+			if result.Err != nil {
+				tracker.lock.Unlock()
+				return fmt.Errorf("failed to refresh allowance of %s for %s: %w", result.Owner, result.Spender, result.Err)
+			}
+
+			tracker.set(entry, keys[i], result.Allowance, entry.block.Number)
+		}
+		tracker.lock.Unlock()
+	}
+
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	entry.previousHead = tracker.head
+	entry.refresh = nil
+
+	head := entry.block
+	tracker.head = &head
+
+	tracker.journal = append(tracker.journal, entry)
+	if len(tracker.journal) > allowanceJournalLimit {
+		tracker.journal = tracker.journal[len(tracker.journal)-allowanceJournalLimit:]
+	}
+
+	return nil
+}
+
+// hasAllowances reports whether owner has outstanding allowances.
+func (tracker *AllowanceTracker) hasAllowances(owner common.Address) bool {
+	tracker.lock.RLock()
+	defer tracker.lock.RUnlock()
+
+	return len(tracker.spenders[owner]) > 0
+}
+
+// set changes the allowance of key, recording its previous state in entry. The caller must hold the lock.
+func (tracker *AllowanceTracker) set(entry *allowanceJournalEntry, key allowanceKey, value *big.Int, block uint64) {
+	if entry != nil {
+		if _, ok := entry.previous[key]; !ok {
+			entry.previous[key] = tracker.allowances[key]
+		}
+	}
+
+	if value.Sign() == 0 {
+		tracker.remove(key)
+		return
+	}
+
+	tracker.store(key, &allowanceState{value: new(big.Int).Set(value), block: block})
+}
+
+// restore puts back the allowances entry's block changed. The caller must hold the lock.
+func (tracker *AllowanceTracker) restore(entry *allowanceJournalEntry) {
+	for key, state := range entry.previous {
+		if state == nil {
+			tracker.remove(key)
+		} else {
+			tracker.store(key, state)
+		}
+	}
+}
+
+// store saves the state of key. The caller must hold the lock.
+func (tracker *AllowanceTracker) store(key allowanceKey, state *allowanceState) {
+	tracker.allowances[key] = state
+
+	spenders, ok := tracker.spenders[key.owner]
+	if !ok {
+		spenders = make(map[common.Address]struct{})
+		tracker.spenders[key.owner] = spenders
+	}
+	spenders[key.spender] = struct{}{}
+}
+
+// remove drops the state of key. The caller must hold the lock.
+func (tracker *AllowanceTracker) remove(key allowanceKey) {
+	delete(tracker.allowances, key)
+
+	delete(tracker.spenders[key.owner], key.spender)
+	if len(tracker.spenders[key.owner]) == 0 {
+		delete(tracker.spenders, key.owner)
+	}
+}
+
+// trackedAllowance returns the exported form of the state of key. The caller must hold the lock.
+func (tracker *AllowanceTracker) trackedAllowance(key allowanceKey) TrackedAllowance {
+	state := tracker.allowances[key]
+
+	return TrackedAllowance{
+		Owner:        key.owner,
+		Spender:      key.spender,
+		Value:        new(big.Int).Set(state.value),
+		Unlimited:    isUnlimitedAllowance(state.value),
+		UpdatedBlock: state.block,
+	}
+}
+
+// newAllowanceJournalEntry creates an empty journal entry for block.
+func newAllowanceJournalEntry(block BlockRef) *allowanceJournalEntry {
+	return &allowanceJournalEntry{
+		block:    block,
+		previous: make(map[allowanceKey]*allowanceState),
+		refresh:  make(map[common.Address]struct{}),
+	}
+}
+
+// isUnlimitedAllowance reports whether value is the maximum allowance, which transferFrom never decreases.
+func isUnlimitedAllowance(value *big.Int) bool {
+	return value.Cmp(math.MaxBig256) == 0
+}
+
+// sortTrackedAllowances orders allowances by owner and spender.
+func sortTrackedAllowances(allowances []TrackedAllowance) {
+	sort.Slice(allowances, func(i, j int) bool {
+		if allowances[i].Owner != allowances[j].Owner {
+			return bytes.Compare(allowances[i].Owner.Bytes(), allowances[j].Owner.Bytes()) < 0
+		}
+
+		return bytes.Compare(allowances[i].Spender.Bytes(), allowances[j].Spender.Bytes()) < 0
+	})
+}
@@ -0,0 +1,186 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testTransactionService serves eth_getTransactionByHash for mined transactions and fails the lookups
+// listed in failures once each.
+type testTransactionService struct {
+	txs      map[common.Hash]json.RawMessage
+	failures map[common.Hash]bool
+}
+
+// GetTransactionByHash serves eth_getTransactionByHash.
+func (service *testTransactionService) GetTransactionByHash(hash common.Hash) (json.RawMessage, error) {
+	if service.failures[hash] {
+		delete(service.failures, hash)
+		return nil, errors.New("connection reset")
+	}
+
+	return service.txs[hash], nil
+}
+
+// mine signs tx with key and serves it as mined at index in block.
+func (service *testTransactionService) mine(t *testing.T, tx *types.Transaction, key *ecdsa.PrivateKey, block BlockRef, index uint) *types.Transaction {
+	t.Helper()
+
+	signer := types.HomesteadSigner{}
+	signedTx, err := types.SignTx(tx, signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := signedTx.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]any
+	err = json.Unmarshal(encoded, &fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields["from"] = crypto.PubkeyToAddress(key.PublicKey)
+	fields["blockHash"] = block.Hash
+	fields["blockNumber"] = hexutil.Uint64(block.Number)
+	fields["transactionIndex"] = hexutil.Uint64(index)
+
+	encoded, err = json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service.txs[signedTx.Hash()] = encoded
+
+	return signedTx
+}
+
+// testEventLog builds a log of the token's event name between the two indexed addresses.
+func testEventLog(token *RebeccaCoinToken, name string, from common.Address, to common.Address, value int64, block BlockRef, tx *types.Transaction, index uint) types.Log {
+	log := types.Log{
+		Address: token.contractAddress,
+		Topics: []common.Hash{
+			token.contractABI.Events[name].ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data:        common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash,
+		Index:       index,
+	}
+	if tx != nil {
+		log.TxHash = tx.Hash()
+		log.TxIndex = index
+	}
+
+	return log
+}
+
+func TestAllowanceTrackerRetriesFailedBlock(t *testing.T) {
+	spenderKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+
+	service := &testTransactionService{
+		txs:      make(map[common.Hash]json.RawMessage),
+		failures: make(map[common.Hash]bool),
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+
+	err = server.RegisterName("eth", service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	token, err := NewRebeccaCoinToken(ethclient.NewClient(client), "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := token.NewAllowanceTracker(nil)
+	ctx := context.Background()
+
+	approveBlock := BlockRef{Number: 1, Hash: common.HexToHash("0x01")}
+	err = tracker.applyBlock(ctx, approveBlock, []types.Log{
+		testEventLog(token, "Approval", owner, spender, 100, approveBlock, nil, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two transferFrom calls by the spender in one block; the sender lookup of the second one fails once.
+	spendBlock := BlockRef{Number: 2, Hash: common.HexToHash("0x02")}
+	logs := make([]types.Log, 2)
+	for i := range logs {
+		data, err := token.contractABI.Pack("transferFrom", owner, recipient, big.NewInt(30))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tx := service.mine(t, types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(1_000_000_000),
+			Gas:      60_000,
+			To:       &token.contractAddress,
+			Data:     data,
+		}), spenderKey, spendBlock, uint(i))
+
+		logs[i] = testEventLog(token, "Transfer", owner, recipient, 30, spendBlock, tx, uint(i))
+	}
+	service.failures[logs[1].TxHash] = true
+
+	err = tracker.applyBlock(ctx, spendBlock, logs)
+	if err == nil {
+		t.Fatal("applyBlock() succeeded, want the injected failure")
+	}
+
+	if got := tracker.Allowance(owner, spender); got.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Allowance() after failure = %s, want 100", got)
+	}
+	if head := tracker.Head(); head == nil || *head != approveBlock {
+		t.Errorf("Head() after failure = %v, want %v", head, approveBlock)
+	}
+
+	err = tracker.applyBlock(ctx, spendBlock, logs)
+	if err != nil {
+		t.Fatalf("applyBlock() retry error = %v", err)
+	}
+
+	if got := tracker.Allowance(owner, spender); got.Cmp(big.NewInt(40)) != 0 {
+		t.Errorf("Allowance() after retry = %s, want 40", got)
+	}
+
+	_, err = tracker.rollbackBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tracker.Allowance(owner, spender); got.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Allowance() after rollback = %s, want 100", got)
+	}
+}
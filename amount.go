@@ -0,0 +1,258 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type (
+	// Amount is a token value with its number of decimals, so it can be read and written
+	// in whole tokens, such as "12.5 RBC", while staying exact. The zero value is zero with no decimals.
+	Amount struct {
+		value    *big.Int
+		decimals uint8
+	}
+
+	// ErrInvalidAmount is returned for amount strings that are malformed, negative, in another token's
+	// unit or more precise than the token's decimals.
+	ErrInvalidAmount struct {
+		Amount string
+		Reason string
+	}
+)
+
+func (err *ErrInvalidAmount) Error() string {
+	return fmt.Sprintf("invalid amount %q: %s", err.Amount, err.Reason)
+}
+
+// NewAmount creates an Amount of value base units of a token with the given decimals.
+func NewAmount(value *big.Int, decimals uint8) Amount {
+	return Amount{
+		value:    new(big.Int).Set(value),
+		decimals: decimals,
+	}
+}
+
+// ParseAmount parses a decimal number of whole tokens, optionally followed by the token symbol,
+// such as "12.5" or "12.5 RBC". An empty symbol accepts no unit.
+func ParseAmount(amount string, decimals uint8, symbol string) (Amount, error) {
+	number, unit, hasUnit := strings.Cut(strings.TrimSpace(amount), " ")
+	if hasUnit {
+		unit = strings.TrimSpace(unit)
+		if symbol == "" || unit != symbol {
+			return Amount{}, &ErrInvalidAmount{Amount: amount, Reason: fmt.Sprintf("unexpected unit %q", unit)}
+		}
+	}
+
+	whole, fraction, hasFraction := strings.Cut(number, ".")
+	if whole == "" || (hasFraction && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Amount{}, &ErrInvalidAmount{Amount: amount, Reason: "not a non-negative decimal number"}
+	}
+	if len(fraction) > int(decimals) {
+		return Amount{}, &ErrInvalidAmount{Amount: amount, Reason: fmt.Sprintf("more than %d decimals", decimals)}
+	}
+
+	value, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+
+	return Amount{
+		value:    value,
+		decimals: decimals,
+	}, nil
+}
+
+// ParseAmount parses an amount of this token, such as "12.5" or "12.5 RBC", using its cached metadata.
+func (token *RebeccaCoinToken) ParseAmount(ctx context.Context, amount string) (Amount, error) {
+	metadata, err := token.Metadata(ctx)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return ParseAmount(amount, metadata.Decimals, metadata.Symbol)
+}
+
+// Amount wraps a raw value of this token using its cached decimals.
+func (token *RebeccaCoinToken) Amount(ctx context.Context, value *big.Int) (Amount, error) {
+	metadata, err := token.Metadata(ctx)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return NewAmount(value, metadata.Decimals), nil
+}
+
+// TransferAmount is Transfer taking an Amount, which must fit the token's decimals.
+func (token *RebeccaCoinToken) TransferAmount(ctx context.Context, to string, amount Amount) (*types.Transaction, error) {
+	_to, err := ParseAddress(to)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := token.amountValue(ctx, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.TransferAddress(ctx, _to, value)
+}
+
+// ApproveAmount is Approve taking an Amount, which must fit the token's decimals.
+func (token *RebeccaCoinToken) ApproveAmount(ctx context.Context, spender string, amount Amount) (*types.Transaction, error) {
+	_spender, err := ParseAddress(spender)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := token.amountValue(ctx, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.ApproveAddress(ctx, _spender, value)
+}
+
+// amountValue returns amount in base units of the token.
+func (token *RebeccaCoinToken) amountValue(ctx context.Context, amount Amount) (*big.Int, error) {
+	metadata, err := token.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals := metadata.Decimals
+
+	if amount.Sign() < 0 {
+		return nil, &ErrInvalidAmount{Amount: amount.String(), Reason: "negative"}
+	}
+
+	value, ok := amount.Rescale(decimals)
+	if !ok {
+		return nil, &ErrInvalidAmount{Amount: amount.String(), Reason: fmt.Sprintf("more than %d decimals", decimals)}
+	}
+
+	return value.Int(), nil
+}
+
+// Int returns the amount in base units.
+func (amount Amount) Int() *big.Int {
+	if amount.value == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(amount.value)
+}
+
+// Decimals returns the number of decimals of the amount.
+func (amount Amount) Decimals() uint8 {
+	return amount.decimals
+}
+
+// Rescale returns the amount with the given decimals, and false if that would drop non-zero digits.
+func (amount Amount) Rescale(decimals uint8) (Amount, bool) {
+	value := amount.Int()
+
+	if decimals >= amount.decimals {
+		value.Mul(value, pow10(decimals-amount.decimals))
+		return Amount{value: value, decimals: decimals}, true
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(value, pow10(amount.decimals-decimals), new(big.Int))
+	if remainder.Sign() != 0 {
+		return Amount{}, false
+	}
+
+	return Amount{value: quotient, decimals: decimals}, true
+}
+
+// Add returns amount + other, with the larger number of decimals of the two.
+func (amount Amount) Add(other Amount) Amount {
+	a, b := align(amount, other)
+	return Amount{value: a.value.Add(a.value, b.value), decimals: a.decimals}
+}
+
+// Sub returns amount - other, with the larger number of decimals of the two. The result may be negative.
+func (amount Amount) Sub(other Amount) Amount {
+	a, b := align(amount, other)
+	return Amount{value: a.value.Sub(a.value, b.value), decimals: a.decimals}
+}
+
+// Cmp compares amount and other and returns -1, 0 or +1.
+func (amount Amount) Cmp(other Amount) int {
+	a, b := align(amount, other)
+	return a.value.Cmp(b.value)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (amount Amount) Sign() int {
+	if amount.value == nil {
+		return 0
+	}
+
+	return amount.value.Sign()
+}
+
+// IsZero reports whether the amount is zero.
+func (amount Amount) IsZero() bool {
+	return amount.Sign() == 0
+}
+
+// String formats the amount in whole tokens without trailing zeros, such as "12.5".
+func (amount Amount) String() string {
+	value := amount.Int()
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+
+	digits := value.String()
+	if amount.decimals == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(amount.decimals) {
+		digits = strings.Repeat("0", int(amount.decimals)-len(digits)+1) + digits
+	}
+
+	split := len(digits) - int(amount.decimals)
+	whole, fraction := digits[:split], strings.TrimRight(digits[split:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + fraction
+}
+
+// Format formats the amount followed by symbol, such as "12.5 RBC".
+func (amount Amount) Format(symbol string) string {
+	return amount.String() + " " + symbol
+}
+
+// align returns copies of a and b with the larger number of decimals of the two.
+func align(a Amount, b Amount) (Amount, Amount) {
+	decimals := max(a.decimals, b.decimals)
+
+	a, _ = a.Rescale(decimals)
+	b, _ = b.Rescale(decimals)
+
+	return a, b
+}
+
+// pow10 returns 10^n.
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
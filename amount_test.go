@@ -0,0 +1,88 @@
+package rebecca_coin_contract
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		symbol   string
+		want     int64
+		wantText string
+		wantErr  bool
+	}{
+		{name: "with symbol", amount: "12.5 RBC", symbol: "RBC", want: 1250000000, wantText: "12.5"},
+		{name: "without symbol", amount: "12.5", symbol: "RBC", want: 1250000000, wantText: "12.5"},
+		{name: "smallest unit", amount: "0.00000001", want: 1, wantText: "0.00000001"},
+		{name: "whole", amount: "3", want: 300000000, wantText: "3"},
+		{name: "too many decimals", amount: "0.000000001", wantErr: true},
+		{name: "trailing dot", amount: "12.", wantErr: true},
+		{name: "leading dot", amount: ".5", wantErr: true},
+		{name: "negative", amount: "-1", wantErr: true},
+		{name: "unit mismatch", amount: "12.5 ETH", symbol: "RBC", wantErr: true},
+		{name: "unit without symbol", amount: "12.5 RBC", wantErr: true},
+		{name: "empty", amount: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseAmount(test.amount, 8, test.symbol)
+			if test.wantErr {
+				var invalid *ErrInvalidAmount
+				if !errors.As(err, &invalid) {
+					t.Fatalf("ParseAmount(%q) error = %v, want ErrInvalidAmount", test.amount, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) error = %v", test.amount, err)
+			}
+
+			if got.Int().Cmp(big.NewInt(test.want)) != 0 {
+				t.Errorf("ParseAmount(%q) = %s base units, want %d", test.amount, got.Int(), test.want)
+			}
+			if got.String() != test.wantText {
+				t.Errorf("String() = %q, want %q", got.String(), test.wantText)
+			}
+		})
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	oneAndHalf := NewAmount(big.NewInt(150000000), 8)
+	two := NewAmount(big.NewInt(2), 0)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "add", got: oneAndHalf.Add(two).String(), want: "3.5"},
+		{name: "negative sub", got: oneAndHalf.Sub(two).String(), want: "-0.5"},
+		{name: "format", got: oneAndHalf.Format("RBC"), want: "1.5 RBC"},
+		{name: "zero value", got: Amount{}.String(), want: "0"},
+		{name: "zero value add", got: Amount{}.Add(oneAndHalf).String(), want: "1.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.want {
+				t.Errorf("got %q, want %q", test.got, test.want)
+			}
+		})
+	}
+
+	if got := oneAndHalf.Cmp(two); got != -1 {
+		t.Errorf("Cmp() = %d, want -1", got)
+	}
+	if !(Amount{}).IsZero() || (Amount{}).Sign() != 0 {
+		t.Error("zero value Amount is not zero")
+	}
+	if got := (Amount{}).Int(); got.Sign() != 0 {
+		t.Errorf("zero value Int() = %s, want 0", got)
+	}
+}
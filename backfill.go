@@ -0,0 +1,333 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// defaultBackfillInitialRange is the block range of the first backfill chunks.
+	defaultBackfillInitialRange = 2_000
+
+	// defaultBackfillMaxRange caps the block range of a backfill chunk.
+	defaultBackfillMaxRange = 100_000
+
+	// defaultBackfillConcurrency is the number of chunks fetched in parallel.
+	defaultBackfillConcurrency = 4
+)
+
+// rangeLimitErrors are fragments of the errors providers return when a log query spans too many blocks or results.
+var rangeLimitErrors = []string{
+	"more than",
+	"too many",
+	"too large",
+	"too wide",
+	"limit exceeded",
+	"size exceeded",
+	"exceed maximum block range",
+	"block range",
+	"timeout",
+}
+
+type (
+	// BackfillOpts configures a historical log backfill.
+	BackfillOpts struct {
+		// FromBlock is the first block to query, usually the token's deployment block.
+		FromBlock uint64
+
+		// ToBlock is the last block to query. Nil uses the latest block when the backfill starts.
+		ToBlock *uint64
+
+		// InitialRange is the block range of the first chunks.
+		InitialRange uint64
+
+		// MaxRange caps the block range a chunk grows to after successful queries.
+		MaxRange uint64
+
+		// Concurrency is the number of chunks fetched in parallel.
+		Concurrency int
+	}
+
+	// LogChunk holds the logs of a contiguous block range, in chain order.
+	LogChunk struct {
+		FromBlock uint64
+		ToBlock   uint64
+		Logs      []types.Log
+	}
+
+	// LogBackfill iterates over the token's historical logs chunk by chunk, in block order.
+	// Chunks are fetched concurrently and their block range adapts to the provider's limits.
+	LogBackfill struct {
+		token  *RebeccaCoinToken
+		query  ethereum.FilterQuery
+		opts   BackfillOpts
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		ordered chan *backfillJob
+		jobs    chan *backfillJob
+
+		rangeLock sync.Mutex
+		rangeSize uint64
+
+		chunk *LogChunk
+		err   error
+	}
+
+	// backfillJob is a block range scheduled for fetching.
+	backfillJob struct {
+		from   uint64
+		to     uint64
+		result chan backfillResult
+	}
+
+	// backfillResult is the outcome of a backfillJob.
+	backfillResult struct {
+		chunk *LogChunk
+		err   error
+	}
+)
+
+// Backfill starts fetching the logs of the named token events, or of all token events if none are named.
+// Call Close when done with the backfill.
+func (token *RebeccaCoinToken) Backfill(ctx context.Context, opts *BackfillOpts, events ...string) (*LogBackfill, error) {
+	var backfillOpts BackfillOpts
+	if opts != nil {
+		backfillOpts = *opts
+	}
+	if backfillOpts.InitialRange == 0 {
+		backfillOpts.InitialRange = defaultBackfillInitialRange
+	}
+	if backfillOpts.MaxRange == 0 {
+		backfillOpts.MaxRange = defaultBackfillMaxRange
+	}
+	if backfillOpts.Concurrency <= 0 {
+		backfillOpts.Concurrency = defaultBackfillConcurrency
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{token.contractAddress},
+	}
+
+	if len(events) > 0 {
+		ids := make([]common.Hash, len(events))
+		for i, name := range events {
+			event, ok := token.contractABI.Events[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown event %s", name)
+			}
+
+			ids[i] = event.ID
+		}
+
+		query.Topics = [][]common.Hash{ids}
+	}
+
+	toBlock := backfillOpts.ToBlock
+	if toBlock == nil {
+		head, err := token.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block number: %w", err)
+		}
+
+		toBlock = &head
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	backfill := &LogBackfill{
+		token:     token,
+		query:     query,
+		opts:      backfillOpts,
+		ctx:       ctx,
+		cancel:    cancel,
+		ordered:   make(chan *backfillJob, backfillOpts.Concurrency),
+		jobs:      make(chan *backfillJob),
+		rangeSize: backfillOpts.InitialRange,
+	}
+
+	for i := 0; i < backfillOpts.Concurrency; i++ {
+		go backfill.work()
+	}
+
+	go backfill.schedule(backfillOpts.FromBlock, *toBlock)
+
+	return backfill, nil
+}
+
+// Next waits for the next chunk and reports whether there is one.
+func (backfill *LogBackfill) Next() bool {
+	if backfill.err != nil {
+		return false
+	}
+
+	job, ok := <-backfill.ordered
+	if !ok {
+		backfill.err = backfill.ctx.Err()
+		backfill.chunk = nil
+		return false
+	}
+
+	select {
+	case result := <-job.result:
+		if result.err != nil {
+			backfill.err = result.err
+			backfill.chunk = nil
+			backfill.cancel()
+			return false
+		}
+
+		backfill.chunk = result.chunk
+		return true
+	case <-backfill.ctx.Done():
+		backfill.err = backfill.ctx.Err()
+		backfill.chunk = nil
+		return false
+	}
+}
+
+// Chunk returns the chunk loaded by the last call to Next.
+func (backfill *LogBackfill) Chunk() *LogChunk {
+	return backfill.chunk
+}
+
+// Err returns the error that stopped the backfill, if any.
+func (backfill *LogBackfill) Err() error {
+	return backfill.err
+}
+
+// Close stops fetching chunks.
+func (backfill *LogBackfill) Close() {
+	backfill.cancel()
+}
+
+// schedule splits [from, to] into jobs sized by the current range size and queues them in order.
+func (backfill *LogBackfill) schedule(from uint64, to uint64) {
+	defer close(backfill.ordered)
+	defer close(backfill.jobs)
+
+	for cursor := from; cursor <= to; {
+		size := backfill.currentRangeSize()
+		end := to
+		if to-cursor >= size {
+			end = cursor + size - 1
+		}
+
+		job := &backfillJob{
+			from:   cursor,
+			to:     end,
+			result: make(chan backfillResult, 1),
+		}
+
+		select {
+		case backfill.ordered <- job:
+		case <-backfill.ctx.Done():
+			return
+		}
+
+		select {
+		case backfill.jobs <- job:
+		case <-backfill.ctx.Done():
+			return
+		}
+
+		if end == to {
+			return
+		}
+
+		cursor = end + 1
+	}
+}
+
+// work fetches queued jobs until the queue is closed.
+func (backfill *LogBackfill) work() {
+	for job := range backfill.jobs {
+		logs, err := backfill.fetch(job.from, job.to)
+		if err != nil {
+			job.result <- backfillResult{err: err}
+			continue
+		}
+
+		job.result <- backfillResult{
+			chunk: &LogChunk{
+				FromBlock: job.from,
+				ToBlock:   job.to,
+				Logs:      logs,
+			},
+		}
+	}
+}
+
+// fetch queries the logs of [from, to], halving the range while the provider rejects it as too large.
+func (backfill *LogBackfill) fetch(from uint64, to uint64) ([]types.Log, error) {
+	query := backfill.query
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = new(big.Int).SetUint64(to)
+
+	logs, err := backfill.token.client.FilterLogs(backfill.ctx, query)
+	if err == nil {
+		backfill.grow()
+		return logs, nil
+	}
+
+	if from == to || !isRangeLimitError(err) {
+		return nil, fmt.Errorf("failed to filter logs of blocks %d-%d: %w", from, to, err)
+	}
+
+	middle := from + (to-from)/2
+	backfill.shrink(middle - from + 1)
+
+	left, err := backfill.fetch(from, middle)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := backfill.fetch(middle+1, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// currentRangeSize returns the block range of the next job.
+func (backfill *LogBackfill) currentRangeSize() uint64 {
+	backfill.rangeLock.Lock()
+	defer backfill.rangeLock.Unlock()
+
+	return backfill.rangeSize
+}
+
+// grow widens the range of later jobs by a quarter after a successful query.
+func (backfill *LogBackfill) grow() {
+	backfill.rangeLock.Lock()
+	defer backfill.rangeLock.Unlock()
+
+	backfill.rangeSize = min(backfill.rangeSize+backfill.rangeSize/4+1, backfill.opts.MaxRange)
+}
+
+// shrink narrows the range of later jobs to at most size after a rejected query.
+func (backfill *LogBackfill) shrink(size uint64) {
+	backfill.rangeLock.Lock()
+	defer backfill.rangeLock.Unlock()
+
+	backfill.rangeSize = max(min(backfill.rangeSize, size), 1)
+}
+
+// isRangeLimitError reports whether the provider rejected a log query for spanning too many blocks or results.
+func isRangeLimitError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range rangeLimitErrors {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,424 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	// CacheOpts configures a cached token.
+	CacheOpts struct {
+		// PollInterval is the delay between head polls when the client does not support subscriptions.
+		PollInterval time.Duration
+
+		// OnError is called with errors the head tracking recovers from by retrying.
+		OnError func(err error)
+	}
+
+	// CachedToken is an ERC20Token that memoizes view results per block hash.
+	// Latest-block reads are served at the tracked head. When a new head extends it, results are carried
+	// over unless the block's Transfer or Approval events touch their addresses; any other head change
+	// empties the cache. Reads at an explicit block hash are kept until the next head, and pending and
+	// block-number reads are not cached.
+	CachedToken struct {
+		token   *RebeccaCoinToken
+		opts    CacheOpts
+		cancel  context.CancelFunc
+		done    chan struct{}
+		lock    sync.RWMutex
+		head    *types.Header
+		entries map[cacheKey]any
+	}
+
+	// cacheKey identifies a view call at a block.
+	cacheKey struct {
+		method string
+		first  common.Address
+		second common.Address
+		block  common.Hash
+	}
+)
+
+var _ ERC20Token = (*CachedToken)(nil)
+
+// Cached wraps the token in a CachedToken that tracks new heads until Close is called or ctx is done.
+func (token *RebeccaCoinToken) Cached(ctx context.Context, opts *CacheOpts) *CachedToken {
+	var cacheOpts CacheOpts
+	if opts != nil {
+		cacheOpts = *opts
+	}
+	if cacheOpts.PollInterval <= 0 {
+		cacheOpts.PollInterval = defaultPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cache := &CachedToken{
+		token:   token,
+		opts:    cacheOpts,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		entries: make(map[cacheKey]any),
+	}
+
+	go func() {
+		defer close(cache.done)
+
+		cache.run(ctx)
+	}()
+
+	return cache
+}
+
+// Close stops tracking heads and waits for the tracking to exit.
+func (cache *CachedToken) Close() {
+	cache.cancel()
+	<-cache.done
+}
+
+// Token returns the wrapped token.
+func (cache *CachedToken) Token() *RebeccaCoinToken {
+	return cache.token
+}
+
+// Allowance returns the amount which _spender is still allowed to withdraw from _owner.
+func (cache *CachedToken) Allowance(ctx context.Context, owner string, spender string, opts ...CallOption) (*big.Int, error) {
+	_owner, err := ParseAddress(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	_spender, err := ParseAddress(spender)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedCall(cache, "allowance", _owner, _spender, opts, copyBig, func(opts []CallOption) (*big.Int, error) {
+		return cache.token.AllowanceAddress(ctx, _owner, _spender, opts...)
+	})
+}
+
+// BalanceOf returns the account balance of another account with address _owner.
+func (cache *CachedToken) BalanceOf(ctx context.Context, address string, opts ...CallOption) (*big.Int, error) {
+	_address, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedCall(cache, "balanceOf", _address, common.Address{}, opts, copyBig, func(opts []CallOption) (*big.Int, error) {
+		return cache.token.BalanceOfAddress(ctx, _address, opts...)
+	})
+}
+
+// Decimals returns the number of decimals the token uses.
+func (cache *CachedToken) Decimals(ctx context.Context, opts ...CallOption) (uint8, error) {
+	return cachedCall(cache, "decimals", common.Address{}, common.Address{}, opts, nil, func(opts []CallOption) (uint8, error) {
+		return cache.token.Decimals(ctx, opts...)
+	})
+}
+
+// Name returns the name of the token.
+func (cache *CachedToken) Name(ctx context.Context, opts ...CallOption) (string, error) {
+	return cachedCall(cache, "name", common.Address{}, common.Address{}, opts, nil, func(opts []CallOption) (string, error) {
+		return cache.token.Name(ctx, opts...)
+	})
+}
+
+// Symbol returns the symbol of the token.
+func (cache *CachedToken) Symbol(ctx context.Context, opts ...CallOption) (string, error) {
+	return cachedCall(cache, "symbol", common.Address{}, common.Address{}, opts, nil, func(opts []CallOption) (string, error) {
+		return cache.token.Symbol(ctx, opts...)
+	})
+}
+
+// TotalSupply returns the total token supply.
+func (cache *CachedToken) TotalSupply(ctx context.Context, opts ...CallOption) (*big.Int, error) {
+	return cachedCall(cache, "totalSupply", common.Address{}, common.Address{}, opts, copyBig, func(opts []CallOption) (*big.Int, error) {
+		return cache.token.TotalSupply(ctx, opts...)
+	})
+}
+
+// Approve allows _spender to withdraw from your account multiple times, up to the _value amount.
+func (cache *CachedToken) Approve(ctx context.Context, spender string, amount *big.Int) (*types.Transaction, error) {
+	return cache.token.Approve(ctx, spender, amount)
+}
+
+// Transfer transfers _value amount of tokens to address _to, and MUST fire the Transfer event.
+func (cache *CachedToken) Transfer(ctx context.Context, to string, amount *big.Int) (*types.Transaction, error) {
+	return cache.token.Transfer(ctx, to, amount)
+}
+
+// TransferFrom transfers _value amount of tokens from address _from to address _to, and MUST fire the Transfer event.
+func (cache *CachedToken) TransferFrom(ctx context.Context, from string, to string, amount *big.Int) (*types.Transaction, error) {
+	return cache.token.TransferFrom(ctx, from, to, amount)
+}
+
+// cachedCall serves the call from the cache when its block is known, and runs and stores it otherwise.
+// Calls against the pending state or a block number, or made before the first head is known, are not cached.
+func cachedCall[T any](cache *CachedToken, method string, first common.Address, second common.Address, opts []CallOption, clone func(T) T, call func(opts []CallOption) (T, error)) (T, error) {
+	options := cache.token.newCallOptions(opts)
+	if options.err != nil || options.pending || options.blockNumber != nil {
+		return call(opts)
+	}
+
+	cache.lock.RLock()
+	head := cache.head
+	cache.lock.RUnlock()
+
+	key := cacheKey{method: method, first: first, second: second}
+	switch {
+	case options.blockHash != nil:
+		key.block = *options.blockHash
+	case head != nil:
+		key.block = head.Hash()
+		opts = append(opts[:len(opts):len(opts)], AtBlockHash(key.block))
+	default:
+		return call(opts)
+	}
+
+	cache.lock.RLock()
+	value, ok := cache.entries[key]
+	cache.lock.RUnlock()
+
+	if ok {
+		result := value.(T)
+		if clone != nil {
+			result = clone(result)
+		}
+
+		return result, nil
+	}
+
+	result, err := call(opts)
+	if err != nil {
+		return result, err
+	}
+
+	stored := result
+	if clone != nil {
+		stored = clone(result)
+	}
+
+	cache.lock.Lock()
+	if options.blockHash != nil || (cache.head != nil && cache.head.Hash() == key.block) {
+		cache.entries[key] = stored
+	}
+	cache.lock.Unlock()
+
+	return result, nil
+}
+
+// run tracks new heads until ctx is done, over a subscription when the client supports it and by polling otherwise.
+func (cache *CachedToken) run(ctx context.Context) {
+	retryDelay := minRetryDelay
+	polling := false
+
+	for ctx.Err() == nil {
+		var err error
+		if polling {
+			err = cache.poll(ctx)
+		} else {
+			err = cache.subscribe(ctx)
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				polling = true
+				continue
+			}
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if cache.opts.OnError != nil {
+				cache.opts.OnError(err)
+			}
+			if !sleepContext(ctx, retryDelay) {
+				return
+			}
+
+			retryDelay = min(retryDelay*2, maxRetryDelay)
+			continue
+		}
+
+		retryDelay = minRetryDelay
+
+		if polling && !sleepContext(ctx, cache.opts.PollInterval) {
+			return
+		}
+	}
+}
+
+// subscribe applies heads from a live subscription until it fails.
+func (cache *CachedToken) subscribe(ctx context.Context) error {
+	headers := make(chan *types.Header)
+
+	subscription, err := cache.token.client.SubscribeNewHead(ctx, headers)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads: %w", err)
+	}
+	defer subscription.Unsubscribe()
+
+	err = cache.poll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-subscription.Err():
+			return fmt.Errorf("new head subscription failed: %w", err)
+		case header := <-headers:
+			err = cache.advance(ctx, header)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// poll applies the latest head.
+func (cache *CachedToken) poll(ctx context.Context) error {
+	header, err := cache.token.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	return cache.advance(ctx, header)
+}
+
+// advance makes header the head. Results at the previous head are carried over when header extends it,
+// except those whose addresses the block's Transfer and Approval events touch.
+func (cache *CachedToken) advance(ctx context.Context, header *types.Header) error {
+	cache.lock.RLock()
+	head := cache.head
+	cache.lock.RUnlock()
+
+	if head != nil && head.Hash() == header.Hash() {
+		return nil
+	}
+
+	var touched func(key cacheKey) bool
+	if head != nil && header.ParentHash == head.Hash() {
+		hash := header.Hash()
+
+		logs, err := cache.token.client.FilterLogs(ctx, ethereum.FilterQuery{
+			BlockHash: &hash,
+			Addresses: []common.Address{cache.token.contractAddress},
+			Topics: [][]common.Hash{{
+				cache.token.contractABI.Events["Transfer"].ID,
+				cache.token.contractABI.Events["Approval"].ID,
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to filter logs of block %d: %w", header.Number, err)
+		}
+
+		touched, err = cache.touchedBy(logs)
+		if err != nil {
+			return err
+		}
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.head != head {
+		// Another head was applied meanwhile; start over from it on the next head.
+		touched = nil
+	}
+
+	entries := make(map[cacheKey]any)
+	if touched != nil {
+		for key, value := range cache.entries {
+			if key.block != head.Hash() || touched(key) {
+				continue
+			}
+
+			key.block = header.Hash()
+			entries[key] = value
+		}
+	}
+
+	cache.entries = entries
+	cache.head = header
+
+	return nil
+}
+
+// touchedBy returns a predicate reporting whether the Transfer and Approval logs may change a cached result.
+func (cache *CachedToken) touchedBy(logs []types.Log) (func(key cacheKey) bool, error) {
+	balances := make(map[common.Address]struct{})
+	owners := make(map[common.Address]struct{})
+	supply := false
+
+	approvalID := cache.token.contractABI.Events["Approval"].ID
+
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+
+		if log.Topics[0] == approvalID {
+			event, err := cache.token.ParseApproval(log)
+			if err != nil {
+				return nil, err
+			}
+
+			owners[event.Owner] = struct{}{}
+			continue
+		}
+
+		event, err := cache.token.ParseTransfer(log)
+		if err != nil {
+			return nil, err
+		}
+
+		balances[event.From] = struct{}{}
+		balances[event.To] = struct{}{}
+
+		// transferFrom spends the owner's allowance without emitting Approval.
+		owners[event.From] = struct{}{}
+
+		if event.From == (common.Address{}) || event.To == (common.Address{}) {
+			supply = true
+		}
+	}
+
+	return func(key cacheKey) bool {
+		switch key.method {
+		case "balanceOf":
+			_, ok := balances[key.first]
+			return ok
+		case "allowance":
+			_, ok := owners[key.first]
+			return ok
+		case "totalSupply":
+			return supply
+		default:
+			return false
+		}
+	}, nil
+}
+
+// copyBig returns a copy of value so cached results cannot be modified by callers.
+func copyBig(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(value)
+}
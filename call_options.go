@@ -0,0 +1,173 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	// CallOption overrides token defaults for a single view call.
+	CallOption func(options *callOptions)
+
+	// callOptions holds the resolved settings of a view call.
+	callOptions struct {
+		from             common.Address
+		blockNumber      *big.Int
+		blockHash        *common.Hash
+		requireCanonical bool
+		pending          bool
+		err              error
+	}
+)
+
+// CallFrom runs the call as if it was sent by the given address.
+func CallFrom(from string) CallOption {
+	return func(options *callOptions) {
+		options.from, options.err = ParseAddress(from)
+	}
+}
+
+// CallFromAddress is CallFrom taking a typed address.
+func CallFromAddress(from common.Address) CallOption {
+	return func(options *callOptions) {
+		options.from = from
+	}
+}
+
+// AtBlockNumber runs the call against the state at the given block number.
+func AtBlockNumber(number *big.Int) CallOption {
+	return func(options *callOptions) {
+		options.blockNumber = number
+		options.blockHash = nil
+		options.pending = false
+	}
+}
+
+// AtBlockHash runs the call against the state at the block with the given hash.
+func AtBlockHash(hash common.Hash) CallOption {
+	return func(options *callOptions) {
+		options.blockNumber = nil
+		options.blockHash = &hash
+		options.requireCanonical = false
+		options.pending = false
+	}
+}
+
+// AtCanonicalBlockHash is like AtBlockHash but fails when the block is no longer canonical (EIP-1898).
+func AtCanonicalBlockHash(hash common.Hash) CallOption {
+	return func(options *callOptions) {
+		options.blockNumber = nil
+		options.blockHash = &hash
+		options.requireCanonical = true
+		options.pending = false
+	}
+}
+
+// AtPending runs the call against the pending state.
+func AtPending() CallOption {
+	return func(options *callOptions) {
+		options.blockNumber = nil
+		options.blockHash = nil
+		options.pending = true
+	}
+}
+
+// AtLatest runs the call against the latest block. This is the default.
+func AtLatest() CallOption {
+	return func(options *callOptions) {
+		options.blockNumber = nil
+		options.blockHash = nil
+		options.pending = false
+	}
+}
+
+// newCallOptions resolves the per-call options against the token defaults.
+func (token *RebeccaCoinToken) newCallOptions(opts []CallOption) *callOptions {
+	options := &callOptions{
+		from: token.defaultFrom(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// defaultFrom returns the caller used for view calls: the configured address,
+// then the signer's account, then the zero address.
+func (token *RebeccaCoinToken) defaultFrom() common.Address {
+	if token.from != nil {
+		return *token.from
+	}
+
+	if token.signer != nil {
+		return token.signer.Address()
+	}
+
+	return common.Address{}
+}
+
+// callContract executes the call against the block selected by the options.
+// Reverts are decoded into the typed errors of this package.
+func (token *RebeccaCoinToken) callContract(ctx context.Context, callMsg ethereum.CallMsg, options *callOptions) ([]byte, error) {
+	if options.err != nil {
+		return nil, options.err
+	}
+
+	var (
+		output []byte
+		err    error
+	)
+
+	switch {
+	case options.pending:
+		output, err = token.client.PendingCallContract(ctx, callMsg)
+	case options.blockHash != nil && options.requireCanonical:
+		var result hexutil.Bytes
+		err = token.client.Client().CallContext(ctx, &result, "eth_call", toCallArg(callMsg), rpc.BlockNumberOrHashWithHash(*options.blockHash, true))
+		output = result
+	case options.blockHash != nil:
+		output, err = token.client.CallContractAtHash(ctx, callMsg, *options.blockHash)
+	default:
+		output, err = token.client.CallContract(ctx, callMsg, options.blockNumber)
+	}
+	if err != nil {
+		return nil, token.decodeError(err)
+	}
+
+	return output, nil
+}
+
+// blockArg encodes the selected block the way eth_call expects it.
+func (options *callOptions) blockArg() any {
+	switch {
+	case options.pending:
+		return "pending"
+	case options.blockHash != nil:
+		return rpc.BlockNumberOrHashWithHash(*options.blockHash, options.requireCanonical)
+	case options.blockNumber != nil:
+		return hexutil.EncodeBig(options.blockNumber)
+	default:
+		return "latest"
+	}
+}
+
+// toCallArg encodes the call message the way eth_call expects it.
+func toCallArg(callMsg ethereum.CallMsg) any {
+	arg := map[string]any{
+		"from": callMsg.From,
+		"to":   callMsg.To,
+	}
+
+	if len(callMsg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(callMsg.Data)
+	}
+
+	return arg
+}
@@ -32,6 +32,7 @@ type ABIElement struct {
 
 // InputOutput represents an input or output in the ABIElement.
 type InputOutput struct {
+	Indexed      bool   `json:"indexed,omitempty"`
 	InternalType string `json:"internalType"`
 	Name         string `json:"name"`
 	Type         string `json:"type"`
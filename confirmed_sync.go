@@ -0,0 +1,174 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type (
+	// confirmedState is state built from token events one confirmed block at a time.
+	confirmedState interface {
+		// syncHead returns the last applied block, or nil if nothing was applied yet.
+		syncHead() (*BlockRef, error)
+
+		// rollbackBlock undoes the newest applied block and returns the new head.
+		rollbackBlock() (*BlockRef, error)
+
+		// applyBlock applies the events of block and makes it the head. Blocks without events are applied
+		// with no logs to checkpoint the sync.
+		applyBlock(ctx context.Context, block BlockRef, logs []types.Log) error
+	}
+
+	// confirmedSync replays token events into a confirmedState up to the latest block minus a confirmation
+	// depth, rolling back blocks that were reorged out of the chain first.
+	confirmedSync struct {
+		token         *RebeccaCoinToken
+		state         confirmedState
+		events        []string
+		startBlock    uint64
+		confirmations uint64
+		backfill      *BackfillOpts
+	}
+)
+
+// sync rewinds the state to the canonical chain and applies the events of the confirmed blocks after its head.
+func (syncer *confirmedSync) sync(ctx context.Context) error {
+	latest, err := syncer.token.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if latest < syncer.confirmations {
+		return nil
+	}
+
+	target := latest - syncer.confirmations
+
+	head, err := syncer.rewind(ctx)
+	if err != nil {
+		return err
+	}
+
+	from := syncer.startBlock
+	if head != nil {
+		from = head.Number + 1
+	}
+	if from > target {
+		return nil
+	}
+
+	var backfillOpts BackfillOpts
+	if syncer.backfill != nil {
+		backfillOpts = *syncer.backfill
+	}
+	backfillOpts.FromBlock = from
+	backfillOpts.ToBlock = &target
+
+	backfill, err := syncer.token.Backfill(ctx, &backfillOpts, syncer.events...)
+	if err != nil {
+		return err
+	}
+	defer backfill.Close()
+
+	for backfill.Next() {
+		err = syncer.applyChunk(ctx, backfill.Chunk())
+		if err != nil {
+			return err
+		}
+	}
+
+	return backfill.Err()
+}
+
+// rewind rolls back applied blocks until the head is part of the canonical chain and returns it.
+func (syncer *confirmedSync) rewind(ctx context.Context) (*BlockRef, error) {
+	head, err := syncer.state.syncHead()
+	if err != nil {
+		return nil, err
+	}
+
+	for head != nil {
+		header, err := syncer.token.client.HeaderByNumber(ctx, new(big.Int).SetUint64(head.Number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get header %d: %w", head.Number, err)
+		}
+		if header.Hash() == head.Hash {
+			break
+		}
+
+		number := head.Number
+		head, err = syncer.state.rollbackBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to roll back block %d: %w", number, err)
+		}
+	}
+
+	return head, nil
+}
+
+// applyChunk applies the events of chunk block by block, then checkpoints the chunk's last block.
+func (syncer *confirmedSync) applyChunk(ctx context.Context, chunk *LogChunk) error {
+	logs := make([]types.Log, 0, len(chunk.Logs))
+	for _, log := range chunk.Logs {
+		if !log.Removed {
+			logs = append(logs, log)
+		}
+	}
+
+	for start := 0; start < len(logs); {
+		end := start + 1
+		for end < len(logs) && logs[end].BlockNumber == logs[start].BlockNumber {
+			end++
+		}
+
+		err := syncer.state.applyBlock(ctx, BlockRef{Number: logs[start].BlockNumber, Hash: logs[start].BlockHash}, logs[start:end])
+		if err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	if len(logs) > 0 && logs[len(logs)-1].BlockNumber == chunk.ToBlock {
+		return nil
+	}
+
+	header, err := syncer.token.client.HeaderByNumber(ctx, new(big.Int).SetUint64(chunk.ToBlock))
+	if err != nil {
+		return fmt.Errorf("failed to get header %d: %w", chunk.ToBlock, err)
+	}
+
+	return syncer.state.applyBlock(ctx, BlockRef{Number: chunk.ToBlock, Hash: header.Hash()}, nil)
+}
+
+// runWithBackoff calls step every interval until ctx is done. Failed steps are reported to onError and
+// retried with an exponential backoff.
+func runWithBackoff(ctx context.Context, interval time.Duration, onError func(err error), step func(ctx context.Context) error) error {
+	retryDelay := minRetryDelay
+
+	for {
+		delay := interval
+
+		err := step(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if onError != nil {
+				onError(err)
+			}
+
+			delay = retryDelay
+			retryDelay = min(retryDelay*2, maxRetryDelay)
+		} else {
+			retryDelay = minRetryDelay
+		}
+
+		if !sleepContext(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package rebecca_coin_contract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	// ErrExecutionReverted is a revert that does not match any of the token's custom errors.
+	// Reason is set when the revert carries an Error(string) message.
+	ErrExecutionReverted struct {
+		Reason string
+		Data   []byte
+	}
+
+	// ErrInsufficientBalance mirrors ERC20InsufficientBalance: the sender's balance is too low.
+	ErrInsufficientBalance struct {
+		Sender  common.Address
+		Balance *big.Int
+		Needed  *big.Int
+	}
+
+	// ErrInsufficientAllowance mirrors ERC20InsufficientAllowance: the spender's allowance is too low.
+	ErrInsufficientAllowance struct {
+		Spender   common.Address
+		Allowance *big.Int
+		Needed    *big.Int
+	}
+
+	// ErrInvalidApprover mirrors ERC20InvalidApprover: the approver is the zero address.
+	ErrInvalidApprover struct {
+		Approver common.Address
+	}
+
+	// ErrInvalidReceiver mirrors ERC20InvalidReceiver: the receiver is the zero address.
+	ErrInvalidReceiver struct {
+		Receiver common.Address
+	}
+
+	// ErrInvalidSender mirrors ERC20InvalidSender: the sender is the zero address.
+	ErrInvalidSender struct {
+		Sender common.Address
+	}
+
+	// ErrInvalidSpender mirrors ERC20InvalidSpender: the spender is the zero address.
+	ErrInvalidSpender struct {
+		Spender common.Address
+	}
+
+	// ErrAccessManagedUnauthorized mirrors AccessManagedUnauthorized: the caller may not call the function.
+	ErrAccessManagedUnauthorized struct {
+		Caller common.Address
+	}
+
+	// ErrAccessManagedRequiredDelay mirrors AccessManagedRequiredDelay: the call must be scheduled first.
+	ErrAccessManagedRequiredDelay struct {
+		Caller common.Address
+		Delay  uint32
+	}
+
+	// ErrAccessManagedInvalidAuthority mirrors AccessManagedInvalidAuthority: the new authority is not a contract.
+	ErrAccessManagedInvalidAuthority struct {
+		Authority common.Address
+	}
+
+	// ErrAccessManagerAlreadyScheduled mirrors AccessManagerAlreadyScheduled: the operation is already scheduled.
+	ErrAccessManagerAlreadyScheduled struct {
+		OperationID common.Hash
+	}
+
+	// ErrAccessManagerNotScheduled mirrors AccessManagerNotScheduled: a delayed call was made without being scheduled.
+	ErrAccessManagerNotScheduled struct {
+		OperationID common.Hash
+	}
+
+	// ErrAccessManagerNotReady mirrors AccessManagerNotReady: the scheduled operation's delay has not passed yet.
+	ErrAccessManagerNotReady struct {
+		OperationID common.Hash
+	}
+
+	// ErrAccessManagerExpired mirrors AccessManagerExpired: the scheduled operation was not executed in time.
+	ErrAccessManagerExpired struct {
+		OperationID common.Hash
+	}
+
+	// ErrAccessManagerUnauthorizedAccount mirrors AccessManagerUnauthorizedAccount: the sender lacks the role.
+	ErrAccessManagerUnauthorizedAccount struct {
+		Sender common.Address
+		RoleID uint64
+	}
+
+	// ErrAccessManagerUnauthorizedCall mirrors AccessManagerUnauthorizedCall: the caller may not call the
+	// selector on target, not even with a delay.
+	ErrAccessManagerUnauthorizedCall struct {
+		Caller   common.Address
+		Target   common.Address
+		Selector [4]byte
+	}
+
+	// ErrAccessManagerUnauthorizedConsume mirrors AccessManagerUnauthorizedConsume: target is not consuming
+	// a scheduled operation.
+	ErrAccessManagerUnauthorizedConsume struct {
+		Target common.Address
+	}
+
+	// ErrExpiredSignature mirrors ERC2612ExpiredSignature: the permit deadline has passed.
+	ErrExpiredSignature struct {
+		Deadline *big.Int
+	}
+
+	// ErrInvalidSigner mirrors ERC2612InvalidSigner: the permit was not signed by the owner.
+	ErrInvalidSigner struct {
+		Signer common.Address
+		Owner  common.Address
+	}
+
+	// ErrInvalidAccountNonce mirrors InvalidAccountNonce: the nonce used is not the account's current one.
+	ErrInvalidAccountNonce struct {
+		Account      common.Address
+		CurrentNonce *big.Int
+	}
+
+	// ErrInvalidSignature mirrors ECDSAInvalidSignature: the signature does not recover to an address.
+	ErrInvalidSignature struct{}
+
+	// ErrInvalidSignatureLength mirrors ECDSAInvalidSignatureLength: the signature is not 64 or 65 bytes long.
+	ErrInvalidSignatureLength struct {
+		Length *big.Int
+	}
+
+	// ErrInvalidSignatureS mirrors ECDSAInvalidSignatureS: the signature's s value is in the upper half order.
+	ErrInvalidSignatureS struct {
+		S [32]byte
+	}
+
+	// ErrInvalidShortString mirrors InvalidShortString.
+	ErrInvalidShortString struct{}
+
+	// ErrStringTooLong mirrors StringTooLong.
+	ErrStringTooLong struct {
+		Str string
+	}
+)
+
+// Error implements the error interface.
+func (err *ErrExecutionReverted) Error() string {
+	if err.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", err.Reason)
+	}
+
+	return fmt.Sprintf("execution reverted: %s", hexutil.Encode(err.Data))
+}
+
+// Error implements the error interface.
+func (err *ErrInsufficientBalance) Error() string {
+	return fmt.Sprintf("insufficient balance of %s: have %s, need %s", err.Sender.Hex(), err.Balance, err.Needed)
+}
+
+// Error implements the error interface.
+func (err *ErrInsufficientAllowance) Error() string {
+	return fmt.Sprintf("insufficient allowance of %s: have %s, need %s", err.Spender.Hex(), err.Allowance, err.Needed)
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidApprover) Error() string {
+	return fmt.Sprintf("invalid approver %s", err.Approver.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidReceiver) Error() string {
+	return fmt.Sprintf("invalid receiver %s", err.Receiver.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSender) Error() string {
+	return fmt.Sprintf("invalid sender %s", err.Sender.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSpender) Error() string {
+	return fmt.Sprintf("invalid spender %s", err.Spender.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagedUnauthorized) Error() string {
+	return fmt.Sprintf("caller %s is not authorized", err.Caller.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagedRequiredDelay) Error() string {
+	return fmt.Sprintf("caller %s must schedule the call with a delay of %d seconds", err.Caller.Hex(), err.Delay)
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagedInvalidAuthority) Error() string {
+	return fmt.Sprintf("invalid authority %s", err.Authority.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerAlreadyScheduled) Error() string {
+	return fmt.Sprintf("operation %s is already scheduled", err.OperationID.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerNotScheduled) Error() string {
+	return fmt.Sprintf("operation %s is not scheduled", err.OperationID.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerNotReady) Error() string {
+	return fmt.Sprintf("operation %s is not ready", err.OperationID.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerExpired) Error() string {
+	return fmt.Sprintf("operation %s expired", err.OperationID.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerUnauthorizedAccount) Error() string {
+	return fmt.Sprintf("account %s lacks role %d", err.Sender.Hex(), err.RoleID)
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerUnauthorizedCall) Error() string {
+	return fmt.Sprintf("caller %s may not call %s on %s", err.Caller.Hex(), hexutil.Encode(err.Selector[:]), err.Target.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrAccessManagerUnauthorizedConsume) Error() string {
+	return fmt.Sprintf("target %s is not consuming a scheduled operation", err.Target.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrExpiredSignature) Error() string {
+	return fmt.Sprintf("permit signature expired at %s", err.Deadline)
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSigner) Error() string {
+	return fmt.Sprintf("permit signed by %s, expected owner %s", err.Signer.Hex(), err.Owner.Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidAccountNonce) Error() string {
+	return fmt.Sprintf("invalid nonce for %s, current nonce is %s", err.Account.Hex(), err.CurrentNonce)
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSignature) Error() string {
+	return "invalid signature"
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSignatureLength) Error() string {
+	return fmt.Sprintf("invalid signature length %s", err.Length)
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidSignatureS) Error() string {
+	return fmt.Sprintf("invalid signature s value %s", common.Hash(err.S).Hex())
+}
+
+// Error implements the error interface.
+func (err *ErrInvalidShortString) Error() string {
+	return "invalid short string"
+}
+
+// Error implements the error interface.
+func (err *ErrStringTooLong) Error() string {
+	return fmt.Sprintf("string too long: %q", err.Str)
+}
+
+// DecodeRevert decodes revert data returned by the token, or by its AccessManager on a restricted call,
+// into one of the typed errors of this package. Data that matches no custom error is returned as *ErrExecutionReverted.
+func (token *RebeccaCoinToken) DecodeRevert(data []byte) error {
+	if len(data) < 4 {
+		return &ErrExecutionReverted{Data: data}
+	}
+
+	decoded := decodeCustomError(token.contractABI.Errors, data)
+	if decoded != nil {
+		return decoded
+	}
+
+	managerABI, err := getAccessManagerABI()
+	if err == nil {
+		decoded = decodeCustomError(managerABI.Errors, data)
+		if decoded != nil {
+			return decoded
+		}
+	}
+
+	reason, err := abi.UnpackRevert(data)
+	if err == nil {
+		return &ErrExecutionReverted{Reason: reason, Data: data}
+	}
+
+	return &ErrExecutionReverted{Data: data}
+}
+
+// decodeError replaces an RPC error carrying revert data with the decoded revert.
+func (token *RebeccaCoinToken) decodeError(err error) error {
+	data, ok := revertData(err)
+	if !ok {
+		return err
+	}
+
+	return token.DecodeRevert(data)
+}
+
+// revertData extracts the revert data carried by an RPC error, reporting whether there was any.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	switch errorData := dataErr.ErrorData().(type) {
+	case string:
+		data, decodeErr := hexutil.Decode(errorData)
+		if decodeErr != nil {
+			return nil, false
+		}
+
+		return data, true
+	case []byte:
+		return errorData, true
+	default:
+		return nil, false
+	}
+}
+
+// decodeCustomError returns the typed error of the custom error among abiErrors that data encodes, or nil.
+func decodeCustomError(abiErrors map[string]abi.Error, data []byte) error {
+	for _, abiError := range abiErrors {
+		if !bytes.Equal(abiError.ID[:4], data[:4]) {
+			continue
+		}
+
+		unpacked, err := abiError.Unpack(data)
+		if err != nil {
+			return nil
+		}
+
+		args, ok := unpacked.([]any)
+		if !ok {
+			return nil
+		}
+
+		return newContractError(abiError.Name, args)
+	}
+
+	return nil
+}
+
+// newContractError builds the typed error for the custom Solidity error name, or nil if unknown.
+func newContractError(name string, args []any) error {
+	switch name {
+	case "AccessManagedInvalidAuthority":
+		return &ErrAccessManagedInvalidAuthority{Authority: args[0].(common.Address)}
+	case "AccessManagedRequiredDelay":
+		return &ErrAccessManagedRequiredDelay{Caller: args[0].(common.Address), Delay: args[1].(uint32)}
+	case "AccessManagedUnauthorized":
+		return &ErrAccessManagedUnauthorized{Caller: args[0].(common.Address)}
+	case "AccessManagerAlreadyScheduled":
+		return &ErrAccessManagerAlreadyScheduled{OperationID: args[0].([32]byte)}
+	case "AccessManagerExpired":
+		return &ErrAccessManagerExpired{OperationID: args[0].([32]byte)}
+	case "AccessManagerNotReady":
+		return &ErrAccessManagerNotReady{OperationID: args[0].([32]byte)}
+	case "AccessManagerNotScheduled":
+		return &ErrAccessManagerNotScheduled{OperationID: args[0].([32]byte)}
+	case "AccessManagerUnauthorizedAccount":
+		return &ErrAccessManagerUnauthorizedAccount{Sender: args[0].(common.Address), RoleID: args[1].(uint64)}
+	case "AccessManagerUnauthorizedCall":
+		return &ErrAccessManagerUnauthorizedCall{Caller: args[0].(common.Address), Target: args[1].(common.Address), Selector: args[2].([4]byte)}
+	case "AccessManagerUnauthorizedConsume":
+		return &ErrAccessManagerUnauthorizedConsume{Target: args[0].(common.Address)}
+	case "ECDSAInvalidSignature":
+		return &ErrInvalidSignature{}
+	case "ECDSAInvalidSignatureLength":
+		return &ErrInvalidSignatureLength{Length: args[0].(*big.Int)}
+	case "ECDSAInvalidSignatureS":
+		return &ErrInvalidSignatureS{S: args[0].([32]byte)}
+	case "ERC20InsufficientAllowance":
+		return &ErrInsufficientAllowance{Spender: args[0].(common.Address), Allowance: args[1].(*big.Int), Needed: args[2].(*big.Int)}
+	case "ERC20InsufficientBalance":
+		return &ErrInsufficientBalance{Sender: args[0].(common.Address), Balance: args[1].(*big.Int), Needed: args[2].(*big.Int)}
+	case "ERC20InvalidApprover":
+		return &ErrInvalidApprover{Approver: args[0].(common.Address)}
+	case "ERC20InvalidReceiver":
+		return &ErrInvalidReceiver{Receiver: args[0].(common.Address)}
+	case "ERC20InvalidSender":
+		return &ErrInvalidSender{Sender: args[0].(common.Address)}
+	case "ERC20InvalidSpender":
+		return &ErrInvalidSpender{Spender: args[0].(common.Address)}
+	case "ERC2612ExpiredSignature":
+		return &ErrExpiredSignature{Deadline: args[0].(*big.Int)}
+	case "ERC2612InvalidSigner":
+		return &ErrInvalidSigner{Signer: args[0].(common.Address), Owner: args[1].(common.Address)}
+	case "InvalidAccountNonce":
+		return &ErrInvalidAccountNonce{Account: args[0].(common.Address), CurrentNonce: args[1].(*big.Int)}
+	case "InvalidShortString":
+		return &ErrInvalidShortString{}
+	case "StringTooLong":
+		return &ErrStringTooLong{Str: args[0].(string)}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package rebecca_coin_contract
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testDataError is an RPC error carrying revert data, as returned by eth_call and eth_estimateGas.
+type testDataError struct {
+	data any
+}
+
+func (err *testDataError) Error() string  { return "execution reverted" }
+func (err *testDataError) ErrorData() any { return err.data }
+
+func newTestToken(t testing.TB) *RebeccaCoinToken {
+	t.Helper()
+
+	token, err := NewRebeccaCoinToken(nil, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// packRevert encodes the custom error name of the token or its AccessManager with args the way the contract
+// reverts with it.
+func packRevert(t *testing.T, token *RebeccaCoinToken, name string, args ...any) []byte {
+	t.Helper()
+
+	managerABI, err := getAccessManagerABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abiError, ok := token.contractABI.Errors[name]
+	if !ok {
+		abiError, ok = managerABI.Errors[name]
+	}
+	if !ok {
+		t.Fatalf("unknown error %s", name)
+	}
+
+	packed, err := abiError.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(append([]byte{}, abiError.ID[:4]...), packed...)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	token := newTestToken(t)
+
+	account := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	other := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	reasonData := append(crypto.Keccak256([]byte("Error(string)"))[:4], common.LeftPadBytes([]byte{0x20}, 32)...)
+	reasonData = append(reasonData, common.LeftPadBytes([]byte{4}, 32)...)
+	reasonData = append(reasonData, common.RightPadBytes([]byte("oops"), 32)...)
+
+	unknownData := []byte{0xde, 0xad, 0xbe, 0xef}
+	operationID := common.HexToHash("0x01")
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{
+			name: "insufficient balance",
+			data: packRevert(t, token, "ERC20InsufficientBalance", account, big.NewInt(1), big.NewInt(2)),
+			want: &ErrInsufficientBalance{Sender: account, Balance: big.NewInt(1), Needed: big.NewInt(2)},
+		},
+		{
+			name: "insufficient allowance",
+			data: packRevert(t, token, "ERC20InsufficientAllowance", account, big.NewInt(3), big.NewInt(4)),
+			want: &ErrInsufficientAllowance{Spender: account, Allowance: big.NewInt(3), Needed: big.NewInt(4)},
+		},
+		{
+			name: "invalid receiver",
+			data: packRevert(t, token, "ERC20InvalidReceiver", common.Address{}),
+			want: &ErrInvalidReceiver{Receiver: common.Address{}},
+		},
+		{
+			name: "required delay",
+			data: packRevert(t, token, "AccessManagedRequiredDelay", account, uint32(3600)),
+			want: &ErrAccessManagedRequiredDelay{Caller: account, Delay: 3600},
+		},
+		{
+			name: "access manager not scheduled",
+			data: packRevert(t, token, "AccessManagerNotScheduled", operationID),
+			want: &ErrAccessManagerNotScheduled{OperationID: operationID},
+		},
+		{
+			name: "access manager not ready",
+			data: packRevert(t, token, "AccessManagerNotReady", operationID),
+			want: &ErrAccessManagerNotReady{OperationID: operationID},
+		},
+		{
+			name: "access manager unauthorized call",
+			data: packRevert(t, token, "AccessManagerUnauthorizedCall", account, other, [4]byte{0x40, 0xc1, 0x0f, 0x19}),
+			want: &ErrAccessManagerUnauthorizedCall{Caller: account, Target: other, Selector: [4]byte{0x40, 0xc1, 0x0f, 0x19}},
+		},
+		{
+			name: "invalid signer",
+			data: packRevert(t, token, "ERC2612InvalidSigner", account, other),
+			want: &ErrInvalidSigner{Signer: account, Owner: other},
+		},
+		{
+			name: "invalid signature",
+			data: packRevert(t, token, "ECDSAInvalidSignature"),
+			want: &ErrInvalidSignature{},
+		},
+		{
+			name: "revert reason",
+			data: reasonData,
+			want: &ErrExecutionReverted{Reason: "oops", Data: reasonData},
+		},
+		{
+			name: "unknown selector",
+			data: unknownData,
+			want: &ErrExecutionReverted{Data: unknownData},
+		},
+		{
+			name: "short data",
+			data: []byte{0x01},
+			want: &ErrExecutionReverted{Data: []byte{0x01}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := token.DecodeRevert(test.data)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("DecodeRevert() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	token := newTestToken(t)
+
+	account := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data := packRevert(t, token, "ERC20InvalidSpender", account)
+	want := &ErrInvalidSpender{Spender: account}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "hex string data", err: &testDataError{data: hexutil.Encode(data)}, want: want},
+		{name: "byte data", err: &testDataError{data: data}, want: want},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := token.decodeError(test.err)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("decodeError() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+
+	t.Run("no data", func(t *testing.T) {
+		err := errors.New("connection refused")
+		if got := token.decodeError(err); got != err {
+			t.Errorf("decodeError() = %v, want the original error", got)
+		}
+	})
+
+	t.Run("undecodable data", func(t *testing.T) {
+		err := &testDataError{data: "not hex"}
+		if got := token.decodeError(err); got != error(err) {
+			t.Errorf("decodeError() = %v, want the original error", got)
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type (
+	// EventMetadata locates a decoded event on chain.
+	EventMetadata struct {
+		BlockNumber uint64
+		BlockHash   common.Hash
+		TxHash      common.Hash
+		TxIndex     uint
+		LogIndex    uint
+		Removed     bool
+	}
+
+	// TransferEvent is emitted when value tokens move from one account to another.
+	// From is the zero address for mints and To is the zero address for burns.
+	TransferEvent struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+		EventMetadata
+	}
+
+	// ApprovalEvent is emitted when the allowance of a spender for an owner is set.
+	ApprovalEvent struct {
+		Owner   common.Address
+		Spender common.Address
+		Value   *big.Int
+		EventMetadata
+	}
+
+	// AuthorityUpdatedEvent is emitted when the token's AccessManager changes.
+	AuthorityUpdatedEvent struct {
+		Authority common.Address
+		EventMetadata
+	}
+)
+
+// FilterTransfers returns the Transfer events between fromBlock and toBlock, or the latest block if toBlock is nil.
+// Empty from or to lists match any address.
+func (token *RebeccaCoinToken) FilterTransfers(ctx context.Context, fromBlock uint64, toBlock *uint64, from []string, to []string) ([]*TransferEvent, error) {
+	topics, err := addressTopics(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := token.filterLogs(ctx, fromBlock, toBlock, "Transfer", topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*TransferEvent, 0, len(logs))
+	for _, log := range logs {
+		event, err := token.ParseTransfer(log)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// FilterApprovals returns the Approval events between fromBlock and toBlock, or the latest block if toBlock is nil.
+// Empty owner or spender lists match any address.
+func (token *RebeccaCoinToken) FilterApprovals(ctx context.Context, fromBlock uint64, toBlock *uint64, owner []string, spender []string) ([]*ApprovalEvent, error) {
+	topics, err := addressTopics(owner, spender)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := token.filterLogs(ctx, fromBlock, toBlock, "Approval", topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*ApprovalEvent, 0, len(logs))
+	for _, log := range logs {
+		event, err := token.ParseApproval(log)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// FilterAuthorityUpdated returns the AuthorityUpdated events between fromBlock and toBlock, or the latest block if toBlock is nil.
+func (token *RebeccaCoinToken) FilterAuthorityUpdated(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]*AuthorityUpdatedEvent, error) {
+	logs, err := token.filterLogs(ctx, fromBlock, toBlock, "AuthorityUpdated")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*AuthorityUpdatedEvent, 0, len(logs))
+	for _, log := range logs {
+		event, err := token.ParseAuthorityUpdated(log)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// ParseTransfer decodes a Transfer log emitted by the token.
+func (token *RebeccaCoinToken) ParseTransfer(log types.Log) (*TransferEvent, error) {
+	err := token.checkEventLog(log, "Transfer")
+	if err != nil {
+		return nil, err
+	}
+	if len(log.Topics) != 3 {
+		return nil, fmt.Errorf("invalid Transfer topic count %d", len(log.Topics))
+	}
+
+	values, err := token.contractABI.Unpack("Transfer", log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack Transfer: %w", err)
+	}
+
+	return &TransferEvent{
+		From:          common.BytesToAddress(log.Topics[1].Bytes()),
+		To:            common.BytesToAddress(log.Topics[2].Bytes()),
+		Value:         values[0].(*big.Int),
+		EventMetadata: newEventMetadata(log),
+	}, nil
+}
+
+// ParseApproval decodes an Approval log emitted by the token.
+func (token *RebeccaCoinToken) ParseApproval(log types.Log) (*ApprovalEvent, error) {
+	err := token.checkEventLog(log, "Approval")
+	if err != nil {
+		return nil, err
+	}
+	if len(log.Topics) != 3 {
+		return nil, fmt.Errorf("invalid Approval topic count %d", len(log.Topics))
+	}
+
+	values, err := token.contractABI.Unpack("Approval", log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack Approval: %w", err)
+	}
+
+	return &ApprovalEvent{
+		Owner:         common.BytesToAddress(log.Topics[1].Bytes()),
+		Spender:       common.BytesToAddress(log.Topics[2].Bytes()),
+		Value:         values[0].(*big.Int),
+		EventMetadata: newEventMetadata(log),
+	}, nil
+}
+
+// ParseAuthorityUpdated decodes an AuthorityUpdated log emitted by the token.
+func (token *RebeccaCoinToken) ParseAuthorityUpdated(log types.Log) (*AuthorityUpdatedEvent, error) {
+	err := token.checkEventLog(log, "AuthorityUpdated")
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := token.contractABI.Unpack("AuthorityUpdated", log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack AuthorityUpdated: %w", err)
+	}
+
+	return &AuthorityUpdatedEvent{
+		Authority:     values[0].(common.Address),
+		EventMetadata: newEventMetadata(log),
+	}, nil
+}
+
+// filterLogs returns the logs of the named token event matching the indexed argument topics.
+func (token *RebeccaCoinToken) filterLogs(ctx context.Context, fromBlock uint64, toBlock *uint64, name string, topics ...[]common.Hash) ([]types.Log, error) {
+	logs, err := token.client.FilterLogs(ctx, token.eventQuery(fromBlock, toBlock, name, topics...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter %s logs: %w", name, err)
+	}
+
+	return logs, nil
+}
+
+// eventQuery builds the log filter of the named token event matching the indexed argument topics.
+func (token *RebeccaCoinToken) eventQuery(fromBlock uint64, toBlock *uint64, name string, topics ...[]common.Hash) ethereum.FilterQuery {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		Addresses: []common.Address{token.contractAddress},
+		Topics:    append([][]common.Hash{{token.contractABI.Events[name].ID}}, topics...),
+	}
+
+	if toBlock != nil {
+		query.ToBlock = new(big.Int).SetUint64(*toBlock)
+	}
+
+	return query
+}
+
+// addressTopics encodes each list of addresses as the filter of an indexed event topic; an empty list matches any address.
+func addressTopics(lists ...[]string) ([][]common.Hash, error) {
+	topics := make([][]common.Hash, len(lists))
+	for i, addresses := range lists {
+		parsed, err := parseAddresses(addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, address := range parsed {
+			topics[i] = append(topics[i], common.BytesToHash(address.Bytes()))
+		}
+	}
+
+	return topics, nil
+}
+
+// checkEventLog makes sure the log was emitted by the token and carries the given event.
+func (token *RebeccaCoinToken) checkEventLog(log types.Log, name string) error {
+	if log.Address != token.contractAddress {
+		return fmt.Errorf("log emitted by %s, not by the token", log.Address.Hex())
+	}
+
+	if len(log.Topics) == 0 || log.Topics[0] != token.contractABI.Events[name].ID {
+		return fmt.Errorf("log is not a %s event", name)
+	}
+
+	return nil
+}
+
+// newEventMetadata extracts the location of the log.
+func newEventMetadata(log types.Log) EventMetadata {
+	return EventMetadata{
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		LogIndex:    log.Index,
+		Removed:     log.Removed,
+	}
+}
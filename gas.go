@@ -0,0 +1,191 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// defaultGasMultiplier is the safety margin applied to gas estimates.
+	defaultGasMultiplier = 1.2
+
+	// defaultBaseFeeMultiplier is the number of base fees the fee cap allows for, so the transaction
+	// stays includable while the base fee rises.
+	defaultBaseFeeMultiplier = 2
+)
+
+type (
+	// Fees are the gas prices of a transaction. GasPrice is set for legacy transactions,
+	// GasTipCap and GasFeeCap for EIP-1559 transactions.
+	Fees struct {
+		GasPrice  *big.Int
+		GasTipCap *big.Int
+		GasFeeCap *big.Int
+	}
+
+	// FeeStrategy prices state-changing calls.
+	FeeStrategy interface {
+		// Fees returns the gas prices of a transaction sent on top of the latest header.
+		Fees(ctx context.Context, client *ethclient.Client, header *types.Header) (*Fees, error)
+	}
+
+	// LegacyFeeStrategy prices transactions with the node's suggested gas price, scaled by Multiplier.
+	LegacyFeeStrategy struct {
+		// Multiplier scales the suggested gas price. Zero leaves it unchanged.
+		Multiplier float64
+	}
+
+	// DynamicFeeStrategy prices EIP-1559 transactions with the node's suggested tip and a fee cap of
+	// the tip plus BaseFeeMultiplier times the latest base fee.
+	DynamicFeeStrategy struct {
+		// BaseFeeMultiplier scales the base fee in the fee cap. Zero uses defaultBaseFeeMultiplier.
+		BaseFeeMultiplier float64
+
+		// GasTipCap replaces the suggested tip when set.
+		GasTipCap *big.Int
+	}
+
+	// FixedFeeStrategy prices every transaction with the same fees: GasPrice for legacy transactions,
+	// or GasTipCap and GasFeeCap for EIP-1559 transactions.
+	FixedFeeStrategy struct {
+		GasPrice  *big.Int
+		GasTipCap *big.Int
+		GasFeeCap *big.Int
+	}
+
+	// AutoFeeStrategy uses DynamicFeeStrategy on chains with a base fee and LegacyFeeStrategy otherwise.
+	AutoFeeStrategy struct {
+		Legacy  LegacyFeeStrategy
+		Dynamic DynamicFeeStrategy
+	}
+
+	// ErrMaxFeeExceeded is returned when a transaction would pay more per gas than the configured ceiling.
+	ErrMaxFeeExceeded struct {
+		FeePerGas    *big.Int
+		MaxFeePerGas *big.Int
+	}
+)
+
+func (err *ErrMaxFeeExceeded) Error() string {
+	return fmt.Sprintf("fee per gas %s exceeds the maximum of %s", err.FeePerGas, err.MaxFeePerGas)
+}
+
+// Fees returns the suggested gas price scaled by Multiplier.
+func (strategy *LegacyFeeStrategy) Fees(ctx context.Context, client *ethclient.Client, header *types.Header) (*Fees, error) {
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price: %w", err)
+	}
+
+	if strategy.Multiplier > 0 {
+		gasPrice = mulFloat(gasPrice, strategy.Multiplier)
+	}
+
+	return &Fees{
+		GasPrice: gasPrice,
+	}, nil
+}
+
+// Fees returns the tip and a fee cap covering BaseFeeMultiplier times the latest base fee.
+func (strategy *DynamicFeeStrategy) Fees(ctx context.Context, client *ethclient.Client, header *types.Header) (*Fees, error) {
+	if header.BaseFee == nil {
+		return nil, fmt.Errorf("block %d has no base fee", header.Number)
+	}
+
+	gasTipCap := strategy.GasTipCap
+	if gasTipCap == nil {
+		var err error
+		gasTipCap, err = client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+		}
+	}
+
+	baseFeeMultiplier := strategy.BaseFeeMultiplier
+	if baseFeeMultiplier <= 0 {
+		baseFeeMultiplier = defaultBaseFeeMultiplier
+	}
+
+	return &Fees{
+		GasTipCap: gasTipCap,
+		GasFeeCap: new(big.Int).Add(gasTipCap, mulFloat(header.BaseFee, baseFeeMultiplier)),
+	}, nil
+}
+
+// Fees returns the fixed fees.
+func (strategy *FixedFeeStrategy) Fees(ctx context.Context, client *ethclient.Client, header *types.Header) (*Fees, error) {
+	return &Fees{
+		GasPrice:  strategy.GasPrice,
+		GasTipCap: strategy.GasTipCap,
+		GasFeeCap: strategy.GasFeeCap,
+	}, nil
+}
+
+// Fees returns dynamic fees on chains with a base fee and a legacy gas price otherwise.
+func (strategy *AutoFeeStrategy) Fees(ctx context.Context, client *ethclient.Client, header *types.Header) (*Fees, error) {
+	if header.BaseFee == nil {
+		return strategy.Legacy.Fees(ctx, client, header)
+	}
+
+	return strategy.Dynamic.Fees(ctx, client, header)
+}
+
+// fees prices a transaction with the configured strategy and enforces the max fee ceiling.
+// A fee cap above the ceiling is lowered to it as long as the latest base fee plus tip still fits.
+func (token *RebeccaCoinToken) fees(ctx context.Context, header *types.Header) (*Fees, error) {
+	fees, err := token.feeStrategy.Fees(ctx, token.client, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if fees.GasPrice == nil && (fees.GasTipCap == nil || fees.GasFeeCap == nil) {
+		return nil, fmt.Errorf("fee strategy returned neither a gas price nor a tip and fee cap")
+	}
+
+	if token.maxFeePerGas == nil {
+		return fees, nil
+	}
+
+	if fees.GasPrice != nil {
+		if fees.GasPrice.Cmp(token.maxFeePerGas) > 0 {
+			return nil, &ErrMaxFeeExceeded{FeePerGas: fees.GasPrice, MaxFeePerGas: token.maxFeePerGas}
+		}
+
+		return fees, nil
+	}
+
+	if fees.GasFeeCap.Cmp(token.maxFeePerGas) <= 0 {
+		return fees, nil
+	}
+
+	required := new(big.Int).Set(fees.GasTipCap)
+	if header.BaseFee != nil {
+		required.Add(required, header.BaseFee)
+	}
+	if required.Cmp(token.maxFeePerGas) > 0 {
+		return nil, &ErrMaxFeeExceeded{FeePerGas: required, MaxFeePerGas: token.maxFeePerGas}
+	}
+
+	fees.GasFeeCap = new(big.Int).Set(token.maxFeePerGas)
+
+	return fees, nil
+}
+
+// applyGasMultiplier adds the configured safety margin to a gas estimate.
+func (token *RebeccaCoinToken) applyGasMultiplier(gas uint64) uint64 {
+	if token.gasMultiplier <= 1 {
+		return gas
+	}
+
+	return mulFloat(new(big.Int).SetUint64(gas), token.gasMultiplier).Uint64()
+}
+
+// mulFloat returns value scaled by factor, rounded down.
+func mulFloat(value *big.Int, factor float64) *big.Int {
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(factor)).Int(nil)
+	return scaled
+}
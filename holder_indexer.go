@@ -0,0 +1,376 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// defaultIndexerConfirmations is the number of blocks the indexer stays behind the latest block.
+	defaultIndexerConfirmations = 12
+
+	// defaultVerifySample is the number of holders checked against the chain by a periodic verification.
+	defaultVerifySample = 100
+)
+
+type (
+	// HolderIndexerOpts configures a holder indexer.
+	HolderIndexerOpts struct {
+		// StartBlock is the first block indexed, usually the token's deployment block.
+		StartBlock uint64
+
+		// Confirmations is the number of blocks the indexer stays behind the latest block.
+		// Zero uses defaultIndexerConfirmations.
+		Confirmations uint64
+
+		// PollInterval is the delay between syncs in Run.
+		PollInterval time.Duration
+
+		// VerifyInterval is the delay between verifications in Run. Zero disables them.
+		VerifyInterval time.Duration
+
+		// VerifySample is the number of random holders checked by a periodic verification.
+		VerifySample int
+
+		// Backfill configures the log queries of a sync.
+		Backfill *BackfillOpts
+
+		// OnError is called with errors Run recovers from by retrying.
+		OnError func(err error)
+
+		// OnVerify is called with the report of each periodic verification.
+		OnVerify func(report *VerificationReport)
+	}
+
+	// HolderIndexer maintains the balance of every holder and the total supply from the token's Transfer events.
+	HolderIndexer struct {
+		token    *RebeccaCoinToken
+		store    HolderStore
+		opts     HolderIndexerOpts
+		syncer   *confirmedSync
+		syncLock sync.Mutex
+	}
+
+	// HolderBalance is the indexed balance of a holder.
+	HolderBalance struct {
+		Holder  common.Address
+		Balance *big.Int
+	}
+
+	// DistributionBucket counts the holders whose balance is within [Min, Max). A nil Max is unbounded.
+	DistributionBucket struct {
+		Min     *big.Int
+		Max     *big.Int
+		Holders int
+		Balance *big.Int
+	}
+
+	// BalanceMismatch is a holder whose indexed balance differs from its on-chain balance.
+	BalanceMismatch struct {
+		Holder  common.Address
+		Indexed *big.Int
+		OnChain *big.Int
+	}
+
+	// VerificationReport compares indexed state with the chain at the indexer's head block.
+	VerificationReport struct {
+		Block         BlockRef
+		Checked       int
+		Mismatches    []BalanceMismatch
+		IndexedSupply *big.Int
+		OnChainSupply *big.Int
+	}
+)
+
+// NewHolderIndexer creates a new HolderIndexer instance storing its state in store.
+func (token *RebeccaCoinToken) NewHolderIndexer(store HolderStore, opts *HolderIndexerOpts) *HolderIndexer {
+	var indexerOpts HolderIndexerOpts
+	if opts != nil {
+		indexerOpts = *opts
+	}
+	if indexerOpts.Confirmations == 0 {
+		indexerOpts.Confirmations = defaultIndexerConfirmations
+	}
+	if indexerOpts.PollInterval <= 0 {
+		indexerOpts.PollInterval = defaultPollInterval
+	}
+	if indexerOpts.VerifySample <= 0 {
+		indexerOpts.VerifySample = defaultVerifySample
+	}
+
+	indexer := &HolderIndexer{
+		token: token,
+		store: store,
+		opts:  indexerOpts,
+	}
+	indexer.syncer = &confirmedSync{
+		token:         token,
+		state:         indexer,
+		events:        []string{"Transfer"},
+		startBlock:    indexerOpts.StartBlock,
+		confirmations: indexerOpts.Confirmations,
+		backfill:      indexerOpts.Backfill,
+	}
+
+	return indexer
+}
+
+// Run syncs the indexer until ctx is done, verifying a sample of holders every VerifyInterval.
+func (indexer *HolderIndexer) Run(ctx context.Context) error {
+	lastVerify := time.Now()
+
+	return runWithBackoff(ctx, indexer.opts.PollInterval, indexer.opts.OnError, func(ctx context.Context) error {
+		err := indexer.Sync(ctx)
+		if err != nil || indexer.opts.VerifyInterval <= 0 || time.Since(lastVerify) < indexer.opts.VerifyInterval {
+			return err
+		}
+
+		report, err := indexer.verifySample(ctx)
+		if err != nil {
+			return err
+		}
+
+		lastVerify = time.Now()
+		if indexer.opts.OnVerify != nil {
+			indexer.opts.OnVerify(report)
+		}
+
+		return nil
+	})
+}
+
+// Sync rolls back blocks that were reorged out of the chain and indexes the Transfer events up to the
+// latest block minus the confirmation depth.
+func (indexer *HolderIndexer) Sync(ctx context.Context) error {
+	indexer.syncLock.Lock()
+	defer indexer.syncLock.Unlock()
+
+	return indexer.syncer.sync(ctx)
+}
+
+// Head returns the last indexed block, or nil if nothing was indexed yet.
+func (indexer *HolderIndexer) Head() (*BlockRef, error) {
+	return indexer.store.Head()
+}
+
+// Balance returns the indexed balance of holder.
+func (indexer *HolderIndexer) Balance(holder common.Address) (*big.Int, error) {
+	return indexer.store.Balance(holder)
+}
+
+// TotalSupply returns the indexed total supply.
+func (indexer *HolderIndexer) TotalSupply() (*big.Int, error) {
+	return indexer.store.TotalSupply()
+}
+
+// TopHolders returns the n holders with the largest balances, largest first.
+func (indexer *HolderIndexer) TopHolders(n int) ([]HolderBalance, error) {
+	top := make([]HolderBalance, 0, n)
+	if n <= 0 {
+		return top, nil
+	}
+
+	err := indexer.store.Holders(func(holder common.Address, balance *big.Int) bool {
+		if len(top) == n && balance.Cmp(top[n-1].Balance) <= 0 {
+			return true
+		}
+
+		i := sort.Search(len(top), func(i int) bool {
+			return top[i].Balance.Cmp(balance) < 0
+		})
+		if len(top) < n {
+			top = append(top, HolderBalance{})
+		}
+		copy(top[i+1:], top[i:])
+		top[i] = HolderBalance{Holder: holder, Balance: balance}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate holders: %w", err)
+	}
+
+	return top, nil
+}
+
+// Distribution groups the holders by balance into buckets split at the given ascending bounds.
+// The first bucket starts at zero and the last one is unbounded.
+func (indexer *HolderIndexer) Distribution(bounds []*big.Int) ([]DistributionBucket, error) {
+	buckets := make([]DistributionBucket, len(bounds)+1)
+	for i := range buckets {
+		buckets[i].Min = new(big.Int)
+		if i > 0 {
+			buckets[i].Min.Set(bounds[i-1])
+		}
+		if i < len(bounds) {
+			buckets[i].Max = new(big.Int).Set(bounds[i])
+		}
+		buckets[i].Balance = new(big.Int)
+	}
+
+	err := indexer.store.Holders(func(holder common.Address, balance *big.Int) bool {
+		i := sort.Search(len(bounds), func(i int) bool {
+			return balance.Cmp(bounds[i]) < 0
+		})
+
+		buckets[i].Holders++
+		buckets[i].Balance.Add(buckets[i].Balance, balance)
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate holders: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// Verify compares the indexed balances of holders and the indexed total supply with the chain at the head block.
+func (indexer *HolderIndexer) Verify(ctx context.Context, holders []common.Address) (*VerificationReport, error) {
+	head, err := indexer.store.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, errors.New("nothing indexed yet")
+	}
+
+	indexedSupply, err := indexer.store.TotalSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	onChainSupply, err := indexer.token.TotalSupply(ctx, AtBlockHash(head.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, len(holders))
+	for i, holder := range holders {
+		addresses[i] = holder.Hex()
+	}
+
+	results, err := indexer.token.BalancesOf(ctx, addresses, AtBlockHash(head.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerificationReport{
+		Block:         *head,
+		Checked:       len(holders),
+		IndexedSupply: indexedSupply,
+		OnChainSupply: onChainSupply,
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", result.Address, result.Err)
+		}
+
+		indexed, err := indexer.store.Balance(holders[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if indexed.Cmp(result.Balance) != 0 {
+			report.Mismatches = append(report.Mismatches, BalanceMismatch{
+				Holder:  holders[i],
+				Indexed: indexed,
+				OnChain: result.Balance,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// Consistent reports whether the verified balances and total supply all match the chain.
+func (report *VerificationReport) Consistent() bool {
+	return len(report.Mismatches) == 0 && report.IndexedSupply.Cmp(report.OnChainSupply) == 0
+}
+
+// verifySample verifies a random sample of VerifySample holders.
+func (indexer *HolderIndexer) verifySample(ctx context.Context) (*VerificationReport, error) {
+	sample := make([]common.Address, 0, indexer.opts.VerifySample)
+	seen := 0
+
+	err := indexer.store.Holders(func(holder common.Address, balance *big.Int) bool {
+		seen++
+		if len(sample) < indexer.opts.VerifySample {
+			sample = append(sample, holder)
+		} else if i := rand.Intn(seen); i < len(sample) {
+			sample[i] = holder
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate holders: %w", err)
+	}
+
+	return indexer.Verify(ctx, sample)
+}
+
+// syncHead returns the last indexed block.
+func (indexer *HolderIndexer) syncHead() (*BlockRef, error) {
+	return indexer.store.Head()
+}
+
+// rollbackBlock undoes the newest indexed block.
+func (indexer *HolderIndexer) rollbackBlock() (*BlockRef, error) {
+	return indexer.store.Rollback()
+}
+
+// applyBlock stores the net effect of the Transfer events of block.
+func (indexer *HolderIndexer) applyBlock(ctx context.Context, block BlockRef, logs []types.Log) error {
+	update := &BlockUpdate{
+		Block:    block,
+		Balances: make(map[common.Address]*big.Int),
+		Supply:   new(big.Int),
+	}
+
+	for _, log := range logs {
+		event, err := indexer.token.ParseTransfer(log)
+		if err != nil {
+			return err
+		}
+
+		if event.From == (common.Address{}) {
+			update.Supply.Add(update.Supply, event.Value)
+		} else {
+			addDelta(update.Balances, event.From, new(big.Int).Neg(event.Value))
+		}
+
+		if event.To == (common.Address{}) {
+			update.Supply.Sub(update.Supply, event.Value)
+		} else {
+			addDelta(update.Balances, event.To, event.Value)
+		}
+	}
+
+	err := indexer.store.Apply(update)
+	if err != nil {
+		return fmt.Errorf("failed to apply block %d: %w", block.Number, err)
+	}
+
+	return nil
+}
+
+// addDelta adds delta to the balance change of holder.
+func addDelta(balances map[common.Address]*big.Int, holder common.Address, delta *big.Int) {
+	if balance, ok := balances[holder]; ok {
+		balance.Add(balance, delta)
+		return
+	}
+
+	balances[holder] = new(big.Int).Set(delta)
+}
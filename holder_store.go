@@ -0,0 +1,420 @@
+package rebecca_coin_contract
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+const (
+	// holderJournalLimit is the number of block updates a holder store can roll back.
+	holderJournalLimit = 1024
+
+	// levelDBCache and levelDBHandles size the LevelDB holder store.
+	levelDBCache   = 16
+	levelDBHandles = 16
+)
+
+// Keys of the holder store layout.
+var (
+	holderHeadKey          = []byte("h")
+	holderSupplyKey        = []byte("s")
+	holderJournalNewestKey = []byte("n")
+	holderJournalOldestKey = []byte("o")
+	holderBalancePrefix    = []byte("b")
+	holderJournalPrefix    = []byte("j")
+)
+
+// ErrReorgTooDeep is returned when a rollback reaches past the updates a holder store remembers.
+var ErrReorgTooDeep = errors.New("reorg deeper than the holder store journal")
+
+type (
+	// BlockRef identifies a block.
+	BlockRef struct {
+		Number uint64      `json:"number"`
+		Hash   common.Hash `json:"hash"`
+	}
+
+	// BlockUpdate is the net effect of a block's transfers on holder balances and total supply.
+	BlockUpdate struct {
+		Block    BlockRef
+		Balances map[common.Address]*big.Int
+		Supply   *big.Int
+	}
+
+	// HolderStore persists holder balances, total supply and the last indexed block.
+	// Apply and Rollback must be atomic.
+	HolderStore interface {
+		// Head returns the last indexed block, or nil if nothing was indexed yet.
+		Head() (*BlockRef, error)
+
+		// Balance returns the indexed balance of holder.
+		Balance(holder common.Address) (*big.Int, error)
+
+		// TotalSupply returns the indexed total supply.
+		TotalSupply() (*big.Int, error)
+
+		// Holders calls fn for every holder with a non-zero balance until fn returns false.
+		Holders(fn func(holder common.Address, balance *big.Int) bool) error
+
+		// Apply adds the update's balance and supply deltas and makes its block the head.
+		Apply(update *BlockUpdate) error
+
+		// Rollback undoes the newest update and returns the new head.
+		Rollback() (*BlockRef, error)
+
+		// Close releases the store.
+		Close() error
+	}
+
+	// KeyValueHolderStore is a HolderStore on top of a go-ethereum key-value database.
+	KeyValueHolderStore struct {
+		db   ethdb.KeyValueStore
+		lock sync.Mutex
+	}
+
+	// holderJournalEntry is the stored form of an applied BlockUpdate.
+	holderJournalEntry struct {
+		Block    BlockRef                  `json:"block"`
+		Balances map[common.Address]string `json:"balances"`
+		Supply   string                    `json:"supply"`
+	}
+)
+
+// NewKeyValueHolderStore creates a new KeyValueHolderStore instance.
+func NewKeyValueHolderStore(db ethdb.KeyValueStore) *KeyValueHolderStore {
+	return &KeyValueHolderStore{
+		db: db,
+	}
+}
+
+// NewMemoryHolderStore creates a HolderStore kept in memory.
+func NewMemoryHolderStore() *KeyValueHolderStore {
+	return NewKeyValueHolderStore(memorydb.New())
+}
+
+// OpenLevelDBHolderStore opens, or creates, a HolderStore in the LevelDB database at path.
+func OpenLevelDBHolderStore(path string) (*KeyValueHolderStore, error) {
+	db, err := leveldb.New(path, levelDBCache, levelDBHandles, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holder store: %w", err)
+	}
+
+	return NewKeyValueHolderStore(db), nil
+}
+
+// Head returns the last indexed block, or nil if nothing was indexed yet.
+func (store *KeyValueHolderStore) Head() (*BlockRef, error) {
+	value, err := store.get(holderHeadKey)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	var head BlockRef
+	err = json.Unmarshal(value, &head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode head: %w", err)
+	}
+
+	return &head, nil
+}
+
+// Balance returns the indexed balance of holder.
+func (store *KeyValueHolderStore) Balance(holder common.Address) (*big.Int, error) {
+	value, err := store.get(holderBalanceKey(holder))
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(value), nil
+}
+
+// TotalSupply returns the indexed total supply.
+func (store *KeyValueHolderStore) TotalSupply() (*big.Int, error) {
+	value, err := store.get(holderSupplyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(value), nil
+}
+
+// Holders calls fn for every holder with a non-zero balance until fn returns false.
+func (store *KeyValueHolderStore) Holders(fn func(holder common.Address, balance *big.Int) bool) error {
+	iterator := store.db.NewIterator(holderBalancePrefix, nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		holder := common.BytesToAddress(iterator.Key()[len(holderBalancePrefix):])
+		if !fn(holder, new(big.Int).SetBytes(iterator.Value())) {
+			break
+		}
+	}
+
+	return iterator.Error()
+}
+
+// Apply adds the update's balance and supply deltas and makes its block the head.
+func (store *KeyValueHolderStore) Apply(update *BlockUpdate) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	batch := store.db.NewBatch()
+
+	err := store.addDeltas(batch, update.Balances, update.Supply, false)
+	if err != nil {
+		return err
+	}
+
+	entry := holderJournalEntry{
+		Block:    update.Block,
+		Balances: make(map[common.Address]string, len(update.Balances)),
+		Supply:   "0",
+	}
+	for holder, delta := range update.Balances {
+		entry.Balances[holder] = delta.String()
+	}
+	if update.Supply != nil {
+		entry.Supply = update.Supply.String()
+	}
+
+	newest, oldest, err := store.journalBounds()
+	if err != nil {
+		return err
+	}
+
+	newest++
+	if oldest == 0 {
+		oldest = newest
+	}
+
+	encodedEntry, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+
+	err = batch.Put(holderJournalKey(newest), encodedEntry)
+	if err != nil {
+		return err
+	}
+
+	for ; newest-oldest >= holderJournalLimit; oldest++ {
+		err = batch.Delete(holderJournalKey(oldest))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = store.putJournalBounds(batch, newest, oldest)
+	if err != nil {
+		return err
+	}
+
+	encodedHead, err := json.Marshal(update.Block)
+	if err != nil {
+		return fmt.Errorf("failed to encode head: %w", err)
+	}
+
+	err = batch.Put(holderHeadKey, encodedHead)
+	if err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// Rollback undoes the newest update and returns the new head.
+// It fails with ErrReorgTooDeep when the update before it is no longer remembered.
+func (store *KeyValueHolderStore) Rollback() (*BlockRef, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	newest, oldest, err := store.journalBounds()
+	if err != nil {
+		return nil, err
+	}
+	if newest == 0 || (newest == oldest && oldest > 1) {
+		return nil, ErrReorgTooDeep
+	}
+
+	entry, err := store.journalEntry(newest)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[common.Address]*big.Int, len(entry.Balances))
+	for holder, delta := range entry.Balances {
+		balances[holder], _ = new(big.Int).SetString(delta, 10)
+	}
+	supply, _ := new(big.Int).SetString(entry.Supply, 10)
+
+	batch := store.db.NewBatch()
+
+	err = store.addDeltas(batch, balances, supply, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = batch.Delete(holderJournalKey(newest))
+	if err != nil {
+		return nil, err
+	}
+
+	var head *BlockRef
+	if newest == oldest {
+		err = store.putJournalBounds(batch, 0, 0)
+		if err == nil {
+			err = batch.Delete(holderHeadKey)
+		}
+	} else {
+		previous, previousErr := store.journalEntry(newest - 1)
+		if previousErr != nil {
+			return nil, previousErr
+		}
+
+		head = &previous.Block
+
+		encodedHead, encodeErr := json.Marshal(head)
+		if encodeErr != nil {
+			return nil, fmt.Errorf("failed to encode head: %w", encodeErr)
+		}
+
+		err = store.putJournalBounds(batch, newest-1, oldest)
+		if err == nil {
+			err = batch.Put(holderHeadKey, encodedHead)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = batch.Write()
+	if err != nil {
+		return nil, err
+	}
+
+	return head, nil
+}
+
+// Close releases the store.
+func (store *KeyValueHolderStore) Close() error {
+	return store.db.Close()
+}
+
+// addDeltas writes the balances and supply with the deltas added, or subtracted when undo is set.
+func (store *KeyValueHolderStore) addDeltas(batch ethdb.Batch, balances map[common.Address]*big.Int, supplyDelta *big.Int, undo bool) error {
+	for holder, delta := range balances {
+		balance, err := store.Balance(holder)
+		if err != nil {
+			return err
+		}
+
+		if undo {
+			balance.Sub(balance, delta)
+		} else {
+			balance.Add(balance, delta)
+		}
+
+		if balance.Sign() < 0 {
+			return fmt.Errorf("negative balance for %s", holder.Hex())
+		}
+
+		if balance.Sign() == 0 {
+			err = batch.Delete(holderBalanceKey(holder))
+		} else {
+			err = batch.Put(holderBalanceKey(holder), balance.Bytes())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if supplyDelta == nil {
+		return nil
+	}
+
+	supply, err := store.TotalSupply()
+	if err != nil {
+		return err
+	}
+
+	if undo {
+		supply.Sub(supply, supplyDelta)
+	} else {
+		supply.Add(supply, supplyDelta)
+	}
+
+	return batch.Put(holderSupplyKey, supply.Bytes())
+}
+
+// journalEntry reads the journal entry with the given sequence number.
+func (store *KeyValueHolderStore) journalEntry(sequence uint64) (*holderJournalEntry, error) {
+	value, err := store.get(holderJournalKey(sequence))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("missing journal entry %d", sequence)
+	}
+
+	var entry holderJournalEntry
+	err = json.Unmarshal(value, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode journal entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// journalBounds returns the sequence numbers of the newest and oldest journal entries, zero if empty.
+func (store *KeyValueHolderStore) journalBounds() (uint64, uint64, error) {
+	newest, err := store.get(holderJournalNewestKey)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	oldest, err := store.get(holderJournalOldestKey)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return new(big.Int).SetBytes(newest).Uint64(), new(big.Int).SetBytes(oldest).Uint64(), nil
+}
+
+// putJournalBounds writes the sequence numbers of the newest and oldest journal entries.
+func (store *KeyValueHolderStore) putJournalBounds(batch ethdb.Batch, newest uint64, oldest uint64) error {
+	err := batch.Put(holderJournalNewestKey, new(big.Int).SetUint64(newest).Bytes())
+	if err != nil {
+		return err
+	}
+
+	return batch.Put(holderJournalOldestKey, new(big.Int).SetUint64(oldest).Bytes())
+}
+
+// get returns the value stored under key, or nil if there is none.
+func (store *KeyValueHolderStore) get(key []byte) ([]byte, error) {
+	ok, err := store.db.Has(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return store.db.Get(key)
+}
+
+// holderBalanceKey returns the key of holder's balance.
+func holderBalanceKey(holder common.Address) []byte {
+	return append(append([]byte{}, holderBalancePrefix...), holder.Bytes()...)
+}
+
+// holderJournalKey returns the key of the journal entry with the given sequence number.
+func holderJournalKey(sequence uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, holderJournalPrefix...), sequence)
+}
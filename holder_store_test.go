@@ -0,0 +1,153 @@
+package rebecca_coin_contract
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testBlockUpdate builds the update of block number with the supply delta and alternating holder/delta pairs.
+func testBlockUpdate(number uint64, supply int64, deltas ...any) *BlockUpdate {
+	update := &BlockUpdate{
+		Block:    BlockRef{Number: number, Hash: common.BigToHash(new(big.Int).SetUint64(number))},
+		Balances: make(map[common.Address]*big.Int, len(deltas)/2),
+		Supply:   big.NewInt(supply),
+	}
+	for i := 0; i < len(deltas); i += 2 {
+		update.Balances[deltas[i].(common.Address)] = big.NewInt(deltas[i+1].(int64))
+	}
+
+	return update
+}
+
+func TestKeyValueHolderStoreApplyRollback(t *testing.T) {
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	mintAndTransfer := []*BlockUpdate{
+		testBlockUpdate(1, 100, alice, int64(100)),
+		testBlockUpdate(2, 0, alice, int64(-30), bob, int64(30)),
+	}
+	mintAndBurn := []*BlockUpdate{
+		testBlockUpdate(1, 100, alice, int64(100)),
+		testBlockUpdate(2, -40, alice, int64(-40)),
+	}
+
+	// One more update than the journal holds, so the first one is trimmed.
+	overflow := make([]*BlockUpdate, holderJournalLimit+1)
+	for i := range overflow {
+		overflow[i] = testBlockUpdate(uint64(i+1), 1, alice, int64(1))
+	}
+
+	tests := []struct {
+		name       string
+		updates    []*BlockUpdate
+		rollbacks  int
+		wantErr    error
+		wantHead   *BlockRef
+		wantAlice  int64
+		wantBob    int64
+		wantSupply int64
+	}{
+		{
+			name:       "apply",
+			updates:    mintAndTransfer,
+			wantHead:   &mintAndTransfer[1].Block,
+			wantAlice:  70,
+			wantBob:    30,
+			wantSupply: 100,
+		},
+		{
+			name:       "rollback",
+			updates:    mintAndTransfer,
+			rollbacks:  1,
+			wantHead:   &mintAndTransfer[0].Block,
+			wantAlice:  100,
+			wantSupply: 100,
+		},
+		{
+			name:      "rollback to empty head",
+			updates:   mintAndTransfer,
+			rollbacks: 2,
+		},
+		{
+			name:      "rollback empty store",
+			rollbacks: 1,
+			wantErr:   ErrReorgTooDeep,
+		},
+		{
+			name:       "rollback past trimmed journal",
+			updates:    overflow,
+			rollbacks:  holderJournalLimit,
+			wantErr:    ErrReorgTooDeep,
+			wantHead:   &overflow[1].Block,
+			wantAlice:  2,
+			wantSupply: 2,
+		},
+		{
+			name:       "mint and burn",
+			updates:    mintAndBurn,
+			wantHead:   &mintAndBurn[1].Block,
+			wantAlice:  60,
+			wantSupply: 60,
+		},
+		{
+			name:       "rollback burn",
+			updates:    mintAndBurn,
+			rollbacks:  1,
+			wantHead:   &mintAndBurn[0].Block,
+			wantAlice:  100,
+			wantSupply: 100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewMemoryHolderStore()
+			defer store.Close()
+
+			for _, update := range test.updates {
+				if err := store.Apply(update); err != nil {
+					t.Fatalf("Apply() error = %v", err)
+				}
+			}
+
+			var err error
+			for i := 0; i < test.rollbacks && err == nil; i++ {
+				_, err = store.Rollback()
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Rollback() error = %v, want %v", err, test.wantErr)
+			}
+
+			head, err := store.Head()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(head, test.wantHead) {
+				t.Errorf("Head() = %v, want %v", head, test.wantHead)
+			}
+
+			for holder, want := range map[common.Address]int64{alice: test.wantAlice, bob: test.wantBob} {
+				balance, err := store.Balance(holder)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if balance.Cmp(big.NewInt(want)) != 0 {
+					t.Errorf("Balance(%s) = %s, want %d", holder.Hex(), balance, want)
+				}
+			}
+
+			supply, err := store.TotalSupply()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if supply.Cmp(big.NewInt(test.wantSupply)) != 0 {
+				t.Errorf("TotalSupply() = %s, want %d", supply, test.wantSupply)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+)
+
+// Metadata holds the immutable descriptive fields of the token.
+type Metadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Metadata returns the token's name, symbol and decimals, fetching them in a single batch on first use
+// and serving them from memory afterwards.
+func (token *RebeccaCoinToken) Metadata(ctx context.Context) (Metadata, error) {
+	token.metadataLock.Lock()
+	defer token.metadataLock.Unlock()
+
+	if token.metadata != nil {
+		return *token.metadata, nil
+	}
+
+	return token.loadMetadata(ctx)
+}
+
+// RefreshMetadata fetches the token's name, symbol and decimals again and replaces the cached ones.
+func (token *RebeccaCoinToken) RefreshMetadata(ctx context.Context) (Metadata, error) {
+	token.metadataLock.Lock()
+	defer token.metadataLock.Unlock()
+
+	return token.loadMetadata(ctx)
+}
+
+// loadMetadata fetches the metadata in one JSON-RPC batch and caches it. The caller must hold the lock.
+func (token *RebeccaCoinToken) loadMetadata(ctx context.Context) (Metadata, error) {
+	methods := []string{"name", "symbol", "decimals"}
+
+	messages := make([][]byte, len(methods))
+	for i, method := range methods {
+		message, err := token.contractABI.Pack(method)
+		if err != nil {
+			return Metadata{}, fmt.Errorf("failed to pack %s message: %w", method, err)
+		}
+
+		messages[i] = message
+	}
+
+	results, err := token.rpcBatchCall(ctx, messages, token.newCallOptions(nil))
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	var metadata Metadata
+	outputs := []any{&metadata.Name, &metadata.Symbol, &metadata.Decimals}
+	for i, result := range results {
+		if result.err != nil {
+			return Metadata{}, result.err
+		}
+
+		err = token.contractABI.UnpackIntoInterface(outputs[i], methods[i], result.output)
+		if err != nil {
+			return Metadata{}, fmt.Errorf("failed to unpack %s: %w", methods[i], err)
+		}
+	}
+
+	token.metadata = &metadata
+
+	return metadata, nil
+}
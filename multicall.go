@@ -0,0 +1,365 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// Multicall3Address is the address Multicall3 is deployed at on most EVM chains.
+	Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+	// defaultBatchSize is the default number of calls sent in a single batch.
+	defaultBatchSize = 500
+
+	// multicallABIJSONSource is the subset of the Multicall3 ABI used for batch reads.
+	multicallABIJSONSource = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "target", "type": "address"},
+					{"internalType": "bool", "name": "allowFailure", "type": "bool"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Call3[]",
+				"name": "calls",
+				"type": "tuple[]"
+			}
+		],
+		"name": "aggregate3",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Result[]",
+				"name": "returnData",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+)
+
+// ErrCallReverted is reported for a batched call that reverted.
+var ErrCallReverted = errors.New("call reverted")
+
+// errMulticallNotDeployed is returned by multicall when the Multicall3 address has no code at the call's block.
+var errMulticallNotDeployed = errors.New("multicall not deployed")
+
+type (
+	// BalanceResult is the outcome of a single balanceOf lookup in a batch.
+	BalanceResult struct {
+		Address string
+		Balance *big.Int
+		Err     error
+	}
+
+	// AllowancePair identifies the allowance of a spender over an owner's tokens.
+	AllowancePair struct {
+		Owner   string
+		Spender string
+	}
+
+	// AllowanceResult is the outcome of a single allowance lookup in a batch.
+	AllowanceResult struct {
+		AllowancePair
+		Allowance *big.Int
+		Err       error
+	}
+
+	// multicallCall mirrors the Multicall3.Call3 struct.
+	multicallCall struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+
+	// multicallResult mirrors the Multicall3.Result struct.
+	multicallResult struct {
+		Success    bool
+		ReturnData []byte
+	}
+
+	// batchResult is the raw outcome of a single call in a batch.
+	batchResult struct {
+		output []byte
+		err    error
+	}
+)
+
+var (
+	multicallABIOnce   sync.Once
+	multicallABIParsed abi.ABI
+	multicallABIErr    error
+)
+
+// BalancesOf returns the balances of many accounts using as few round-trips as possible.
+// A failure for a single account, including an invalid address, is reported in its result instead of failing
+// the whole batch. Results are in the order of addresses.
+func (token *RebeccaCoinToken) BalancesOf(ctx context.Context, addresses []string, opts ...CallOption) ([]BalanceResult, error) {
+	results := make([]BalanceResult, len(addresses))
+	messages := make([][]byte, 0, len(addresses))
+	indexes := make([]int, 0, len(addresses))
+	for i, address := range addresses {
+		results[i].Address = address
+
+		parsed, err := ParseAddress(address)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		message, err := token.contractABI.Pack("balanceOf", parsed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack balanceOf message: %w", err)
+		}
+
+		messages = append(messages, message)
+		indexes = append(indexes, i)
+	}
+
+	outputs, err := token.batchCall(ctx, messages, token.newCallOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	for j, output := range outputs {
+		i := indexes[j]
+
+		if output.err != nil {
+			results[i].Err = output.err
+			continue
+		}
+
+		var balance *big.Int
+		err = token.contractABI.UnpackIntoInterface(&balance, "balanceOf", output.output)
+		if err != nil {
+			results[i].Err = fmt.Errorf("failed to unpack balanceOf: %w", err)
+			continue
+		}
+
+		results[i].Balance = balance
+	}
+
+	return results, nil
+}
+
+// Allowances returns the allowances of many owner/spender pairs using as few round-trips as possible.
+// A failure for a single pair, including an invalid address, is reported in its result instead of failing
+// the whole batch. Results are in the order of pairs.
+func (token *RebeccaCoinToken) Allowances(ctx context.Context, pairs []AllowancePair, opts ...CallOption) ([]AllowanceResult, error) {
+	results := make([]AllowanceResult, len(pairs))
+	messages := make([][]byte, 0, len(pairs))
+	indexes := make([]int, 0, len(pairs))
+	for i, pair := range pairs {
+		results[i].AllowancePair = pair
+
+		owner, err := ParseAddress(pair.Owner)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		spender, err := ParseAddress(pair.Spender)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		message, err := token.contractABI.Pack("allowance", owner, spender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack allowance message: %w", err)
+		}
+
+		messages = append(messages, message)
+		indexes = append(indexes, i)
+	}
+
+	outputs, err := token.batchCall(ctx, messages, token.newCallOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	for j, output := range outputs {
+		i := indexes[j]
+
+		if output.err != nil {
+			results[i].Err = output.err
+			continue
+		}
+
+		var allowance *big.Int
+		err = token.contractABI.UnpackIntoInterface(&allowance, "allowance", output.output)
+		if err != nil {
+			results[i].Err = fmt.Errorf("failed to unpack allowance: %w", err)
+			continue
+		}
+
+		results[i].Allowance = allowance
+	}
+
+	return results, nil
+}
+
+// batchCall runs the token calls in chunks, through Multicall3 when it is deployed and JSON-RPC batches otherwise.
+func (token *RebeccaCoinToken) batchCall(ctx context.Context, messages [][]byte, options *callOptions) ([]batchResult, error) {
+	if options.err != nil {
+		return nil, options.err
+	}
+
+	useMulticall := token.multicallAddress != (common.Address{}) && !token.multicallMissing.Load()
+
+	var err error
+	results := make([]batchResult, 0, len(messages))
+	for start := 0; start < len(messages); start += token.batchSize {
+		end := min(start+token.batchSize, len(messages))
+		chunk := messages[start:end]
+
+		var chunkResults []batchResult
+		if useMulticall {
+			chunkResults, err = token.multicall(ctx, chunk, options)
+			if errors.Is(err, errMulticallNotDeployed) && options.blockHash == nil && options.blockNumber == nil {
+				// Multicall3 is missing from the current state, so later batches skip it. Calls pinned to
+				// a block may predate its deployment and say nothing about the chain today.
+				token.multicallMissing.Store(true)
+				useMulticall = false
+			}
+			if err != nil && ctx.Err() == nil {
+				// The aggregate call itself failed, e.g. because it ran out of gas or Multicall3 is not
+				// deployed at the call's block; retry the chunk call by call.
+				chunkResults, err = token.rpcBatchCall(ctx, chunk, options)
+			}
+		} else {
+			chunkResults, err = token.rpcBatchCall(ctx, chunk, options)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+// multicall runs the calls in a single Multicall3 aggregate3 call.
+func (token *RebeccaCoinToken) multicall(ctx context.Context, messages [][]byte, options *callOptions) ([]batchResult, error) {
+	multicallABI, err := getMulticallABI()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]multicallCall, len(messages))
+	for i, message := range messages {
+		calls[i] = multicallCall{
+			Target:       token.contractAddress,
+			AllowFailure: true,
+			CallData:     message,
+		}
+	}
+
+	message, err := multicallABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack aggregate3 message: %w", err)
+	}
+
+	callMsg := ethereum.CallMsg{
+		From: options.from,
+		To:   &token.multicallAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call multicall: %w", err)
+	}
+	if len(output) == 0 {
+		// Calls to an address without code succeed with empty output.
+		return nil, errMulticallNotDeployed
+	}
+
+	unpacked, err := multicallABI.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack aggregate3: %w", err)
+	}
+
+	multicallResults := *abi.ConvertType(unpacked[0], new([]multicallResult)).(*[]multicallResult)
+	if len(multicallResults) != len(messages) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(multicallResults), len(messages))
+	}
+
+	results := make([]batchResult, len(messages))
+	for i, result := range multicallResults {
+		if !result.Success {
+			results[i].err = fmt.Errorf("%w: %w", ErrCallReverted, token.DecodeRevert(result.ReturnData))
+			continue
+		}
+
+		results[i].output = result.ReturnData
+	}
+
+	return results, nil
+}
+
+// rpcBatchCall runs the calls as a single JSON-RPC batch of eth_call requests.
+func (token *RebeccaCoinToken) rpcBatchCall(ctx context.Context, messages [][]byte, options *callOptions) ([]batchResult, error) {
+	outputs := make([]hexutil.Bytes, len(messages))
+	elems := make([]rpc.BatchElem, len(messages))
+	for i, message := range messages {
+		callMsg := ethereum.CallMsg{
+			From: options.from,
+			To:   &token.contractAddress,
+			Data: message,
+		}
+
+		elems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []any{toCallArg(callMsg), options.blockArg()},
+			Result: &outputs[i],
+		}
+	}
+
+	err := token.client.Client().BatchCallContext(ctx, elems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send batch call: %w", err)
+	}
+
+	results := make([]batchResult, len(messages))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			results[i].err = fmt.Errorf("failed to call contract: %w", token.decodeError(elem.Error))
+			continue
+		}
+
+		results[i].output = outputs[i]
+	}
+
+	return results, nil
+}
+
+// getMulticallABI parses the Multicall3 ABI on first use.
+func getMulticallABI() (abi.ABI, error) {
+	multicallABIOnce.Do(func() {
+		multicallABIParsed, multicallABIErr = abi.JSON(strings.NewReader(multicallABIJSONSource))
+	})
+	if multicallABIErr != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse multicall ABI: %w", multicallABIErr)
+	}
+
+	return multicallABIParsed, nil
+}
@@ -0,0 +1,125 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testCallService serves eth_call by returning the last byte of the call data as a uint256, so every
+// balanceOf and allowance call answers with the last byte of its last address.
+type testCallService struct{}
+
+// Call serves eth_call.
+func (service *testCallService) Call(args map[string]any, block string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.LeftPadBytes(data[len(data)-1:], 32), nil
+}
+
+func newTestCallToken(t *testing.T) *RebeccaCoinToken {
+	t.Helper()
+
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+
+	err := server.RegisterName("eth", &testCallService{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+
+	token, err := NewRebeccaCoinToken(ethclient.NewClient(client), "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", WithMulticallAddress(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestBalancesOfInvalidAddress(t *testing.T) {
+	token := newTestCallToken(t)
+
+	addresses := []string{
+		"0x0000000000000000000000000000000000000001",
+		"0xnot-an-address",
+		"0x0000000000000000000000000000000000000003",
+	}
+
+	results, err := token.BalancesOf(context.Background(), addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(addresses) {
+		t.Fatalf("BalancesOf() returned %d results, want %d", len(results), len(addresses))
+	}
+
+	for i, want := range []int64{1, -1, 3} {
+		result := results[i]
+		if result.Address != addresses[i] {
+			t.Errorf("results[%d].Address = %s, want %s", i, result.Address, addresses[i])
+		}
+
+		if want < 0 {
+			var invalid *ErrInvalidAddress
+			if !errors.As(result.Err, &invalid) {
+				t.Errorf("results[%d].Err = %v, want ErrInvalidAddress", i, result.Err)
+			}
+			continue
+		}
+
+		if result.Err != nil || result.Balance.Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("results[%d] = %s, %v, want %d", i, result.Balance, result.Err, want)
+		}
+	}
+}
+
+func TestAllowancesInvalidAddress(t *testing.T) {
+	token := newTestCallToken(t)
+
+	pairs := []AllowancePair{
+		{Owner: "0x0000000000000000000000000000000000000001", Spender: "0x0000000000000000000000000000000000000002"},
+		{Owner: "0x0000000000000000000000000000000000000001", Spender: "bad"},
+		{Owner: "0x0000000000000000000000000000000000000003", Spender: "0x0000000000000000000000000000000000000004"},
+	}
+
+	results, err := token.Allowances(context.Background(), pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(pairs) {
+		t.Fatalf("Allowances() returned %d results, want %d", len(results), len(pairs))
+	}
+
+	for i, want := range []int64{2, -1, 4} {
+		result := results[i]
+		if result.AllowancePair != pairs[i] {
+			t.Errorf("results[%d] pair = %v, want %v", i, result.AllowancePair, pairs[i])
+		}
+
+		if want < 0 {
+			var invalid *ErrInvalidAddress
+			if !errors.As(result.Err, &invalid) {
+				t.Errorf("results[%d].Err = %v, want ErrInvalidAddress", i, result.Err)
+			}
+			continue
+		}
+
+		if result.Err != nil || result.Allowance.Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("results[%d] = %s, %v, want %d", i, result.Allowance, result.Err, want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// nonceKeyPrefix prefixes the keys of account nonce states in a KeyValueNonceStore.
+var nonceKeyPrefix = []byte("nonce")
+
+// nonceErrors are fragments of the errors nodes return when a transaction's nonce is already used.
+var nonceErrors = []string{
+	"nonce too low",
+	"replacement transaction underpriced",
+}
+
+type (
+	// NonceState is the persisted nonce bookkeeping of an account.
+	NonceState struct {
+		// Next is the lowest nonce never handed out.
+		Next uint64 `json:"next"`
+
+		// Released are nonces below Next that were handed back unused. They are handed out first.
+		Released []uint64 `json:"released"`
+
+		// Reserved are nonces handed out whose transactions are not known to be sent yet. After a restart
+		// they are treated as possibly broadcast and only handed out again once Resync finds the node
+		// never received them.
+		Reserved []uint64 `json:"reserved"`
+	}
+
+	// NonceStore persists the nonce states of accounts.
+	NonceStore interface {
+		// LoadNonces returns the saved state of account, or nil if there is none.
+		LoadNonces(account common.Address) (*NonceState, error)
+
+		// SaveNonces saves the state of account.
+		SaveNonces(account common.Address, state *NonceState) error
+	}
+
+	// KeyValueNonceStore is a NonceStore on top of a go-ethereum key-value database.
+	KeyValueNonceStore struct {
+		db ethdb.KeyValueStore
+	}
+
+	// NonceManagerOpts configures a nonce manager.
+	NonceManagerOpts struct {
+		// OnError is called with errors that do not fail the transaction they occur in, such as failing
+		// to persist the release of a nonce whose transaction was already broadcast.
+		OnError func(err error)
+	}
+
+	// NonceManager hands out sequential nonces to concurrent senders from the same accounts.
+	// Nonces of transactions that fail before broadcast are reused, so no gaps are left behind,
+	// and in-flight nonces are persisted so a restart does not hand them out twice.
+	NonceManager struct {
+		client   *ethclient.Client
+		store    NonceStore
+		opts     NonceManagerOpts
+		lock     sync.Mutex
+		accounts map[common.Address]*NonceState
+	}
+)
+
+// NewKeyValueNonceStore creates a new KeyValueNonceStore instance.
+func NewKeyValueNonceStore(db ethdb.KeyValueStore) *KeyValueNonceStore {
+	return &KeyValueNonceStore{
+		db: db,
+	}
+}
+
+// LoadNonces returns the saved state of account, or nil if there is none.
+func (store *KeyValueNonceStore) LoadNonces(account common.Address) (*NonceState, error) {
+	key := append(append([]byte{}, nonceKeyPrefix...), account.Bytes()...)
+
+	ok, err := store.db.Has(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	value, err := store.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var state NonceState
+	err = json.Unmarshal(value, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nonce state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SaveNonces saves the state of account.
+func (store *KeyValueNonceStore) SaveNonces(account common.Address, state *NonceState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode nonce state: %w", err)
+	}
+
+	return store.db.Put(append(append([]byte{}, nonceKeyPrefix...), account.Bytes()...), value)
+}
+
+// NewNonceManager creates a new NonceManager instance persisting its state in store.
+// A nil store keeps the state in memory only.
+func NewNonceManager(client *ethclient.Client, store NonceStore, opts *NonceManagerOpts) *NonceManager {
+	if store == nil {
+		store = NewKeyValueNonceStore(memorydb.New())
+	}
+
+	var managerOpts NonceManagerOpts
+	if opts != nil {
+		managerOpts = *opts
+	}
+
+	return &NonceManager{
+		client:   client,
+		store:    store,
+		opts:     managerOpts,
+		accounts: make(map[common.Address]*NonceState),
+	}
+}
+
+// Reserve hands out the next nonce of account. Every reserved nonce must be given back with Release.
+func (manager *NonceManager) Reserve(ctx context.Context, account common.Address) (uint64, error) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	state, err := manager.state(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce uint64
+	reused := len(state.Released) > 0
+	if reused {
+		nonce = state.Released[0]
+		state.Released = state.Released[1:]
+	} else {
+		nonce = state.Next
+		state.Next++
+	}
+
+	state.Reserved = insertNonce(state.Reserved, nonce)
+
+	err = manager.save(account, state)
+	if err != nil {
+		// Undo the reservation so the nonce is handed out again.
+		i, _ := slices.BinarySearch(state.Reserved, nonce)
+		state.Reserved = slices.Delete(state.Reserved, i, i+1)
+		if reused {
+			state.Released = insertNonce(state.Released, nonce)
+		} else {
+			state.Next--
+		}
+
+		return 0, err
+	}
+
+	return nonce, nil
+}
+
+// Release gives back a reserved nonce. Unless its transaction was sent, the nonce is handed out again.
+func (manager *NonceManager) Release(account common.Address, nonce uint64, sent bool) error {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	state, ok := manager.accounts[account]
+	if !ok {
+		return fmt.Errorf("nonce %d of %s is not reserved", nonce, account.Hex())
+	}
+
+	i, found := slices.BinarySearch(state.Reserved, nonce)
+	if !found {
+		return fmt.Errorf("nonce %d of %s is not reserved", nonce, account.Hex())
+	}
+
+	state.Reserved = slices.Delete(state.Reserved, i, i+1)
+	if !sent {
+		state.Released = insertNonce(state.Released, nonce)
+	}
+
+	return manager.save(account, state)
+}
+
+// Resync realigns the nonces of account with the node's pending nonce, after a nonce was rejected as used
+// or transactions were dropped. Nonces between the pending nonce and the reserved ones are handed out again.
+func (manager *NonceManager) Resync(ctx context.Context, account common.Address) error {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	state, err := manager.state(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	pending, err := manager.client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	state.Reserved = slices.DeleteFunc(state.Reserved, func(nonce uint64) bool {
+		return nonce < pending
+	})
+
+	state.Next = pending
+	if len(state.Reserved) > 0 {
+		state.Next = max(state.Next, state.Reserved[len(state.Reserved)-1]+1)
+	}
+
+	state.Released = nil
+	for nonce := pending; nonce < state.Next; nonce++ {
+		if _, reserved := slices.BinarySearch(state.Reserved, nonce); !reserved {
+			state.Released = append(state.Released, nonce)
+		}
+	}
+
+	return manager.save(account, state)
+}
+
+// state returns the state of account, loading it from the store and reconciling it with the node on first use.
+// The caller must hold the lock.
+func (manager *NonceManager) state(ctx context.Context, account common.Address) (*NonceState, error) {
+	if state, ok := manager.accounts[account]; ok {
+		return state, nil
+	}
+
+	pending, err := manager.client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	saved, err := manager.store.LoadNonces(account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nonces: %w", err)
+	}
+
+	state := &NonceState{
+		Next: pending,
+	}
+
+	if saved != nil {
+		// Only nonces the previous run released unused are handed out again. Its sent and reserved nonces
+		// may be waiting in the node's queue behind a gap, so reusing them could replace a broadcast
+		// transaction; Resync hands them out once the node shows they were dropped.
+		state.Next = max(saved.Next, pending)
+		for _, nonce := range saved.Released {
+			if nonce >= pending && nonce < state.Next {
+				state.Released = insertNonce(state.Released, nonce)
+			}
+		}
+	}
+
+	manager.accounts[account] = state
+
+	return state, nil
+}
+
+// reportError passes an error that does not fail the current call to OnError.
+func (manager *NonceManager) reportError(err error) {
+	if manager.opts.OnError != nil {
+		manager.opts.OnError(err)
+	}
+}
+
+// save persists the state of account.
+func (manager *NonceManager) save(account common.Address, state *NonceState) error {
+	err := manager.store.SaveNonces(account, state)
+	if err != nil {
+		return fmt.Errorf("failed to save nonces: %w", err)
+	}
+
+	return nil
+}
+
+// insertNonce adds nonce to the sorted nonces if it is not there yet.
+func insertNonce(nonces []uint64, nonce uint64) []uint64 {
+	i, found := slices.BinarySearch(nonces, nonce)
+	if found {
+		return nonces
+	}
+
+	return slices.Insert(nonces, i, nonce)
+}
+
+// isNonceError reports whether the node rejected a transaction because its nonce is already used.
+func isNonceError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range nonceErrors {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
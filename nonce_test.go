@@ -0,0 +1,107 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testNonceService serves eth_getTransactionCount with a fixed pending nonce.
+type testNonceService struct {
+	pending uint64
+}
+
+// GetTransactionCount serves eth_getTransactionCount.
+func (service *testNonceService) GetTransactionCount(account common.Address, block string) (hexutil.Uint64, error) {
+	return hexutil.Uint64(service.pending), nil
+}
+
+func TestNonceManagerRestart(t *testing.T) {
+	account := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	tests := []struct {
+		name        string
+		saved       *NonceState
+		pending     uint64
+		want        []uint64
+		afterResync []uint64
+	}{
+		{
+			name:        "no saved state",
+			pending:     5,
+			want:        []uint64{5, 6},
+			afterResync: []uint64{7},
+		},
+		{
+			name:        "reserved above pending",
+			saved:       &NonceState{Next: 10, Released: []uint64{9}, Reserved: []uint64{7, 8}},
+			pending:     7,
+			want:        []uint64{9, 10, 11},
+			afterResync: []uint64{7, 8, 12},
+		},
+		{
+			name:        "pending past reserved",
+			saved:       &NonceState{Next: 10, Released: []uint64{9}, Reserved: []uint64{7, 8}},
+			pending:     10,
+			want:        []uint64{10, 11},
+			afterResync: []uint64{12},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := rpc.NewServer()
+			defer server.Stop()
+
+			err := server.RegisterName("eth", &testNonceService{pending: test.pending})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := rpc.DialInProc(server)
+			defer client.Close()
+
+			store := NewKeyValueNonceStore(memorydb.New())
+			if test.saved != nil {
+				err = store.SaveNonces(account, test.saved)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			manager := NewNonceManager(ethclient.NewClient(client), store, nil)
+			ctx := context.Background()
+
+			reserve := func(n int) []uint64 {
+				nonces := make([]uint64, n)
+				for i := range nonces {
+					nonces[i], err = manager.Reserve(ctx, account)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				return nonces
+			}
+
+			if got := reserve(len(test.want)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Reserve() = %v, want %v", got, test.want)
+			}
+
+			err = manager.Resync(ctx, account)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := reserve(len(test.afterResync)); !reflect.DeepEqual(got, test.afterResync) {
+				t.Errorf("Reserve() after Resync = %v, want %v", got, test.afterResync)
+			}
+		})
+	}
+}
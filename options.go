@@ -0,0 +1,108 @@
+package rebecca_coin_contract
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Option configures a RebeccaCoinToken at construction time.
+type Option func(token *RebeccaCoinToken)
+
+// WithSigner sets the signer used for state-changing calls.
+func WithSigner(signer Signer) Option {
+	return func(token *RebeccaCoinToken) {
+		token.signer = signer
+	}
+}
+
+// WithTransactOpts sets the transactor used to sign state-changing calls.
+// Only the From and Signer fields are used; see bind.NewKeyedTransactorWithChainID
+// and bind.NewTransactorWithChainID for building one from a private key or a keystore.
+func WithTransactOpts(opts *bind.TransactOpts) Option {
+	return WithSigner(NewTransactOptsSigner(opts))
+}
+
+// WithFrom sets the default caller address for view calls.
+func WithFrom(from string) Option {
+	return func(token *RebeccaCoinToken) {
+		address, err := ParseAddress(from)
+		if err != nil {
+			token.optionErr = fmt.Errorf("invalid from address: %w", err)
+			return
+		}
+
+		token.from = &address
+	}
+}
+
+// WithMulticallAddress sets the Multicall3 contract used by batch reads.
+// An empty address disables Multicall3 and batch reads use JSON-RPC batches instead.
+func WithMulticallAddress(address string) Option {
+	return func(token *RebeccaCoinToken) {
+		if address == "" {
+			token.multicallAddress = common.Address{}
+			return
+		}
+
+		multicallAddress, err := ParseAddress(address)
+		if err != nil {
+			token.optionErr = fmt.Errorf("invalid multicall address: %w", err)
+			return
+		}
+
+		token.multicallAddress = multicallAddress
+	}
+}
+
+// WithBatchSize sets the number of calls sent in a single Multicall3 or JSON-RPC batch.
+func WithBatchSize(size int) Option {
+	return func(token *RebeccaCoinToken) {
+		if size > 0 {
+			token.batchSize = size
+		}
+	}
+}
+
+// WithNonceManager sets the nonce manager used to assign nonces to state-changing calls.
+// Without one, each transaction uses the node's pending nonce.
+func WithNonceManager(manager *NonceManager) Option {
+	return func(token *RebeccaCoinToken) {
+		token.nonceManager = manager
+	}
+}
+
+// WithFeeStrategy sets the strategy pricing state-changing calls. The default is AutoFeeStrategy.
+func WithFeeStrategy(strategy FeeStrategy) Option {
+	return func(token *RebeccaCoinToken) {
+		if strategy != nil {
+			token.feeStrategy = strategy
+		}
+	}
+}
+
+// WithGasMultiplier sets the safety margin applied to gas estimates, such as 1.2 for 20% extra gas.
+func WithGasMultiplier(multiplier float64) Option {
+	return func(token *RebeccaCoinToken) {
+		if multiplier > 0 {
+			token.gasMultiplier = multiplier
+		}
+	}
+}
+
+// WithMaxFeePerGas sets the most a state-changing call may pay per gas. Calls that would pay more fail
+// with ErrMaxFeeExceeded.
+func WithMaxFeePerGas(maxFeePerGas *big.Int) Option {
+	return func(token *RebeccaCoinToken) {
+		token.maxFeePerGas = maxFeePerGas
+	}
+}
+
+// WithAllowZeroAddress lets Transfer and TransferFrom send tokens to the zero address, which burns them for good.
+func WithAllowZeroAddress() Option {
+	return func(token *RebeccaCoinToken) {
+		token.allowZeroAddress = true
+	}
+}
@@ -0,0 +1,134 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EIP712Domain is the EIP-712 domain the token signs permits with, as reported by eip712Domain (EIP-5267).
+type EIP712Domain struct {
+	Fields            [1]byte        `abi:"fields"`
+	Name              string         `abi:"name"`
+	Version           string         `abi:"version"`
+	ChainID           *big.Int       `abi:"chainId"`
+	VerifyingContract common.Address `abi:"verifyingContract"`
+	Salt              [32]byte       `abi:"salt"`
+	Extensions        []*big.Int     `abi:"extensions"`
+}
+
+// DomainSeparator returns the EIP-712 domain separator used to sign permits.
+func (token *RebeccaCoinToken) DomainSeparator(ctx context.Context, opts ...CallOption) ([32]byte, error) {
+	message, err := token.contractABI.Pack("DOMAIN_SEPARATOR")
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to pack DOMAIN_SEPARATOR message: %w", err)
+	}
+
+	callOptions := token.newCallOptions(opts)
+
+	callMsg := ethereum.CallMsg{
+		From: callOptions.from,
+		To:   &token.contractAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var domainSeparator [32]byte
+	err = token.contractABI.UnpackIntoInterface(&domainSeparator, "DOMAIN_SEPARATOR", output)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to unpack DOMAIN_SEPARATOR: %w", err)
+	}
+
+	return domainSeparator, nil
+}
+
+// EIP712Domain returns the fields of the EIP-712 domain used to sign permits.
+func (token *RebeccaCoinToken) EIP712Domain(ctx context.Context, opts ...CallOption) (*EIP712Domain, error) {
+	message, err := token.contractABI.Pack("eip712Domain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack eip712Domain message: %w", err)
+	}
+
+	callOptions := token.newCallOptions(opts)
+
+	callMsg := ethereum.CallMsg{
+		From: callOptions.from,
+		To:   &token.contractAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var domain EIP712Domain
+	err = token.contractABI.UnpackIntoInterface(&domain, "eip712Domain", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack eip712Domain: %w", err)
+	}
+
+	return &domain, nil
+}
+
+// Nonces returns the current permit nonce of owner.
+func (token *RebeccaCoinToken) Nonces(ctx context.Context, owner string, opts ...CallOption) (*big.Int, error) {
+	_owner, err := ParseAddress(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := token.contractABI.Pack("nonces", _owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack nonces message: %w", err)
+	}
+
+	callOptions := token.newCallOptions(opts)
+
+	callMsg := ethereum.CallMsg{
+		From: callOptions.from,
+		To:   &token.contractAddress,
+		Data: message,
+	}
+
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var nonce *big.Int
+	err = token.contractABI.UnpackIntoInterface(&nonce, "nonces", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack nonces: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// Permit submits a signed EIP-2612 permit that sets spender's allowance over owner's tokens to value.
+func (token *RebeccaCoinToken) Permit(ctx context.Context, owner string, spender string, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	_owner, err := ParseAddress(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	_spender, err := ParseAddress(spender)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := token.transact(ctx, "permit", _owner, _spender, value, deadline, v, r, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact permit: %w", err)
+	}
+
+	return tx, nil
+}
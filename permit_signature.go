@@ -0,0 +1,216 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// EIP-5267 bits of EIP712Domain.Fields telling which domain fields are in use.
+const (
+	domainFieldName              = 0x01
+	domainFieldVersion           = 0x02
+	domainFieldChainID           = 0x04
+	domainFieldVerifyingContract = 0x08
+	domainFieldSalt              = 0x10
+)
+
+// secp256k1HalfN is the upper bound of s accepted by OpenZeppelin's ECDSA.recover.
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// PermitSignature is an EIP-2612 permit signed off-chain by the token owner.
+type PermitSignature struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+	V        uint8
+	R        [32]byte
+	S        [32]byte
+}
+
+// SignPermit builds and signs a permit letting spender use value of the signer's tokens until deadline.
+// The domain and nonce are read from the token.
+func (token *RebeccaCoinToken) SignPermit(ctx context.Context, signer TypedDataSigner, spender string, value *big.Int, deadline *big.Int) (*PermitSignature, error) {
+	owner := signer.Address()
+
+	_spender, err := ParseAddress(spender)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := token.EIP712Domain(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EIP-712 domain: %w", err)
+	}
+
+	nonce, err := token.Nonces(ctx, owner.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	permit := &PermitSignature{
+		Owner:    owner,
+		Spender:  _spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}
+
+	signature, err := signer.SignTypedData(ctx, permit.typedData(domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign permit: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+
+	copy(permit.R[:], signature[:32])
+	copy(permit.S[:], signature[32:64])
+	permit.V = signature[64]
+	if permit.V < 27 {
+		permit.V += 27
+	}
+
+	return permit, nil
+}
+
+// VerifyPermit checks the permit the way the token would and returns *ErrExpiredSignature,
+// *ErrInvalidAccountNonce, *ErrInvalidSignature, *ErrInvalidSignatureS or *ErrInvalidSigner
+// when submitting it would revert.
+func (token *RebeccaCoinToken) VerifyPermit(ctx context.Context, permit *PermitSignature) error {
+	header, err := token.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	if new(big.Int).SetUint64(header.Time).Cmp(permit.Deadline) > 0 {
+		return &ErrExpiredSignature{Deadline: permit.Deadline}
+	}
+
+	nonce, err := token.Nonces(ctx, permit.Owner.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	if nonce.Cmp(permit.Nonce) != 0 {
+		return &ErrInvalidAccountNonce{Account: permit.Owner, CurrentNonce: nonce}
+	}
+
+	domain, err := token.EIP712Domain(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get EIP-712 domain: %w", err)
+	}
+
+	signer, err := permit.recover(domain)
+	if err != nil {
+		return err
+	}
+
+	if signer != permit.Owner {
+		return &ErrInvalidSigner{Signer: signer, Owner: permit.Owner}
+	}
+
+	return nil
+}
+
+// SubmitPermit verifies the permit locally and submits it to the token.
+func (token *RebeccaCoinToken) SubmitPermit(ctx context.Context, permit *PermitSignature) (*types.Transaction, error) {
+	err := token.VerifyPermit(ctx, permit)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.Permit(ctx, permit.Owner.Hex(), permit.Spender.Hex(), permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+}
+
+// recover returns the address that signed the permit under the given domain.
+func (permit *PermitSignature) recover(domain *EIP712Domain) (common.Address, error) {
+	if !crypto.ValidateSignatureValues(0, new(big.Int).SetBytes(permit.R[:]), new(big.Int).SetBytes(permit.S[:]), false) {
+		return common.Address{}, &ErrInvalidSignature{}
+	}
+
+	if new(big.Int).SetBytes(permit.S[:]).Cmp(secp256k1HalfN) > 0 {
+		return common.Address{}, &ErrInvalidSignatureS{S: permit.S}
+	}
+
+	if permit.V != 27 && permit.V != 28 {
+		return common.Address{}, &ErrInvalidSignature{}
+	}
+
+	digest, _, err := apitypes.TypedDataAndHash(permit.typedData(domain))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to hash permit: %w", err)
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature[:32], permit.R[:])
+	copy(signature[32:64], permit.S[:])
+	signature[64] = permit.V - 27
+
+	publicKey, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return common.Address{}, &ErrInvalidSignature{}
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// typedData returns the EIP-712 Permit message under the given domain.
+func (permit *PermitSignature) typedData(domain *EIP712Domain) apitypes.TypedData {
+	var (
+		domainTypes     []apitypes.Type
+		typedDataDomain apitypes.TypedDataDomain
+	)
+
+	fields := domain.Fields[0]
+	if fields&domainFieldName != 0 {
+		domainTypes = append(domainTypes, apitypes.Type{Name: "name", Type: "string"})
+		typedDataDomain.Name = domain.Name
+	}
+	if fields&domainFieldVersion != 0 {
+		domainTypes = append(domainTypes, apitypes.Type{Name: "version", Type: "string"})
+		typedDataDomain.Version = domain.Version
+	}
+	if fields&domainFieldChainID != 0 {
+		domainTypes = append(domainTypes, apitypes.Type{Name: "chainId", Type: "uint256"})
+		typedDataDomain.ChainId = (*math.HexOrDecimal256)(domain.ChainID)
+	}
+	if fields&domainFieldVerifyingContract != 0 {
+		domainTypes = append(domainTypes, apitypes.Type{Name: "verifyingContract", Type: "address"})
+		typedDataDomain.VerifyingContract = domain.VerifyingContract.Hex()
+	}
+	if fields&domainFieldSalt != 0 {
+		domainTypes = append(domainTypes, apitypes.Type{Name: "salt", Type: "bytes32"})
+		typedDataDomain.Salt = common.Hash(domain.Salt).Hex()
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainTypes,
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain:      typedDataDomain,
+		Message: apitypes.TypedDataMessage{
+			"owner":    permit.Owner.Hex(),
+			"spender":  permit.Spender.Hex(),
+			"value":    (*math.HexOrDecimal256)(permit.Value),
+			"nonce":    (*math.HexOrDecimal256)(permit.Nonce),
+			"deadline": (*math.HexOrDecimal256)(permit.Deadline),
+		},
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"math/big"
 
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -19,55 +22,64 @@ type (
 	ERC20Token interface {
 		// Name returns the name of the token.
 		// function name() external view returns (string memory);
-		Name(ctx context.Context) (string, error)
+		Name(ctx context.Context, opts ...CallOption) (string, error)
 
 		// Symbol returns the symbol of the token.
 		// function symbol() external view returns (string memory);
-		Symbol(ctx context.Context) (string, error)
+		Symbol(ctx context.Context, opts ...CallOption) (string, error)
 
 		// Decimals returns the number of decimals the token uses.
 		// function decimals() external view returns(uint8);
-		Decimals(ctx context.Context) (uint8, error)
+		Decimals(ctx context.Context, opts ...CallOption) (uint8, error)
 
 		// TotalSupply returns the total token supply.
 		// function totalSupply() external view returns (uint256);
-		TotalSupply(ctx context.Context) (*big.Int, error)
+		TotalSupply(ctx context.Context, opts ...CallOption) (*big.Int, error)
 
 		// BalanceOf returns the account balance of another account with address _owner.
 		// function balanceOf(address _owner) external view returns (uint256);
-		BalanceOf(ctx context.Context, address string) (*big.Int, error)
+		BalanceOf(ctx context.Context, address string, opts ...CallOption) (*big.Int, error)
 
 		// Transfer transfers _value amount of tokens to address _to, and MUST fire the Transfer event.
 		// function transfer(address _to, uint256 _value) external returns(bool);
-		Transfer(ctx context.Context, to string, amount *big.Int) (bool, error)
+		Transfer(ctx context.Context, to string, amount *big.Int) (*types.Transaction, error)
 
 		// TransferFrom transfers _value amount of tokens from address _from to address _to, and MUST fire the Transfer event.
 		// function transferFrom(address _from, address _to, uint256 _value) external returns (bool success);
-		TransferFrom(ctx context.Context, from, to string, amount *big.Int) (bool, error)
+		TransferFrom(ctx context.Context, from, to string, amount *big.Int) (*types.Transaction, error)
 
 		// Approve allows _spender to withdraw from your account multiple times, up to the _value amount.
 		// function approve(address _spender, uint256 _value) external returns (bool success);
-		Approve(ctx context.Context, spender string, amount *big.Int) (bool, error)
+		Approve(ctx context.Context, spender string, amount *big.Int) (*types.Transaction, error)
 
 		// Allowance returns the amount which _spender is still allowed to withdraw from _owner.
 		// function allowance(address _owner, address _spender) external view returns (uint256 remaining);
-		Allowance(ctx context.Context, owner, spender string) (*big.Int, error)
+		Allowance(ctx context.Context, owner, spender string, opts ...CallOption) (*big.Int, error)
 	}
 
 	// RebeccaCoinToken is the implementaiont of the ERC20 token
 	RebeccaCoinToken struct {
-		client                *ethclient.Client
-		contractAddress       common.Address
-		contractABIJSONSource string
+		client           *ethclient.Client
+		contractAddress  common.Address
+		contractABI      abi.ABI
+		signer           Signer
+		from             *common.Address
+		multicallAddress common.Address
+		multicallMissing atomic.Bool
+		batchSize        int
+		nonceManager     *NonceManager
+		feeStrategy      FeeStrategy
+		gasMultiplier    float64
+		maxFeePerGas     *big.Int
+		allowZeroAddress bool
+		optionErr        error
+		metadataLock     sync.Mutex
+		metadata         *Metadata
 	}
 )
 
-// NewRebeccaCoinToken creates a new RebeccaCoinToken instance.
-func NewRebeccaCoinToken(client *ethclient.Client, contractAddress string) *RebeccaCoinToken {
-	return &RebeccaCoinToken{
-		client:          client,
-		contractAddress: common.HexToAddress(contractAddress),
-		contractABIJSONSource: `[
+// contractABIJSONSource is the JSON ABI of the RebeccaCoin contract.
+const contractABIJSONSource = `[
 	{
 		"inputs": [
 			{
@@ -292,11 +304,13 @@ func NewRebeccaCoinToken(client *ethclient.Client, contractAddress string) *Rebe
 	{
 		"inputs": [
 			{
+				"indexed": true,
 				"internalType": "address",
 				"name": "owner",
 				"type": "address"
 			},
 			{
+				"indexed": true,
 				"internalType": "address",
 				"name": "spender",
 				"type": "address"
@@ -329,11 +343,13 @@ func NewRebeccaCoinToken(client *ethclient.Client, contractAddress string) *Rebe
 	{
 		"inputs": [
 			{
+				"indexed": true,
 				"internalType": "address",
 				"name": "from",
 				"type": "address"
 			},
 			{
+				"indexed": true,
 				"internalType": "address",
 				"name": "to",
 				"type": "address"
@@ -691,121 +707,141 @@ func NewRebeccaCoinToken(client *ethclient.Client, contractAddress string) *Rebe
 			}
 		]
 	}
-]`,
-	}
-}
+]`
 
-// Allowance returns the amount which _spender is still allowed to withdraw from _owner.
-func (token *RebeccaCoinToken) Allowance(ctx context.Context, owner string, spender string) (*big.Int, error) {
-	abi, err := token.getContractABI()
+// NewRebeccaCoinToken creates a new RebeccaCoinToken instance.
+func NewRebeccaCoinToken(client *ethclient.Client, contractAddress string, options ...Option) (*RebeccaCoinToken, error) {
+	contractABI, err := abi.JSON(strings.NewReader(contractABIJSONSource))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get contract ABI: %w", err)
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
 	}
 
-	_owner := common.HexToAddress(owner)
-	_spender := common.HexToAddress(spender)
-
-	message, err := abi.Pack("allowance", _owner, _spender)
+	_contractAddress, err := ParseAddress(contractAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pack allowance message: %w", err)
+		return nil, fmt.Errorf("invalid contract address: %w", err)
 	}
 
-	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
-		To:   &token.contractAddress,
-		Data: message,
+	token := &RebeccaCoinToken{
+		client:           client,
+		contractAddress:  _contractAddress,
+		contractABI:      contractABI,
+		multicallAddress: common.HexToAddress(Multicall3Address),
+		batchSize:        defaultBatchSize,
+		feeStrategy:      &AutoFeeStrategy{},
+		gasMultiplier:    defaultGasMultiplier,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block number: %w", err)
+	for _, option := range options {
+		option(token)
+	}
+	if token.optionErr != nil {
+		return nil, token.optionErr
 	}
 
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	return token, nil
+}
+
+// ABI returns the parsed contract ABI.
+func (token *RebeccaCoinToken) ABI() abi.ABI {
+	return token.contractABI
+}
+
+// Allowance returns the amount which _spender is still allowed to withdraw from _owner.
+func (token *RebeccaCoinToken) Allowance(ctx context.Context, owner string, spender string, opts ...CallOption) (*big.Int, error) {
+	_owner, err := ParseAddress(owner)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %w", err)
+		return nil, err
 	}
 
-	var allowance *big.Int
-	err = abi.UnpackIntoInterface(&allowance, "allowance", output)
+	_spender, err := ParseAddress(spender)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unpack allowance: %w", err)
+		return nil, err
 	}
 
-	return allowance, nil
+	return token.AllowanceAddress(ctx, _owner, _spender, opts...)
 }
 
-// Approve allows _spender to withdraw from your account multiple times, up to the _value amount.
-func (token *RebeccaCoinToken) Approve(ctx context.Context, spender string, amount *big.Int) (bool, error) {
-	abi, err := token.getContractABI()
+// AllowanceAddress is Allowance taking typed addresses.
+func (token *RebeccaCoinToken) AllowanceAddress(ctx context.Context, _owner common.Address, _spender common.Address, opts ...CallOption) (*big.Int, error) {
+	message, err := token.contractABI.Pack("allowance", _owner, _spender)
 	if err != nil {
-		return false, fmt.Errorf("failed to get contract ABI: %w", err)
+		return nil, fmt.Errorf("failed to pack allowance message: %w", err)
 	}
 
-	_spender := common.HexToAddress(spender)
-
-	message, err := abi.Pack("approve", _spender, amount)
-	if err != nil {
-		return false, fmt.Errorf("failed to pack approve message: %w", err)
-	}
+	callOptions := token.newCallOptions(opts)
 
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
+	output, err := token.callContract(ctx, callMsg, callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var allowance *big.Int
+	err = token.contractABI.UnpackIntoInterface(&allowance, "allowance", output)
 	if err != nil {
-		return false, fmt.Errorf("failed to get block number: %w", err)
+		return nil, fmt.Errorf("failed to unpack allowance: %w", err)
 	}
 
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	return allowance, nil
+}
+
+// Approve allows _spender to withdraw from your account multiple times, up to the _value amount.
+func (token *RebeccaCoinToken) Approve(ctx context.Context, spender string, amount *big.Int) (*types.Transaction, error) {
+	_spender, err := ParseAddress(spender)
 	if err != nil {
-		return false, fmt.Errorf("failed to call contract: %w", err)
+		return nil, err
 	}
 
-	var success bool
-	err = abi.UnpackIntoInterface(&success, "approve", output)
+	return token.ApproveAddress(ctx, _spender, amount)
+}
+
+// ApproveAddress is Approve taking a typed address.
+func (token *RebeccaCoinToken) ApproveAddress(ctx context.Context, _spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	tx, err := token.transact(ctx, "approve", _spender, amount)
 	if err != nil {
-		return false, fmt.Errorf("failed to unpack approve: %w", err)
+		return nil, fmt.Errorf("failed to transact approve: %w", err)
 	}
 
-	return success, nil
+	return tx, nil
 }
 
 // BalanceOf returns the account balance of another account with address _owner.
-func (token *RebeccaCoinToken) BalanceOf(ctx context.Context, address string) (*big.Int, error) {
-	abi, err := token.getContractABI()
+func (token *RebeccaCoinToken) BalanceOf(ctx context.Context, address string, opts ...CallOption) (*big.Int, error) {
+	_address, err := ParseAddress(address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get contract ABI: %w", err)
+		return nil, err
 	}
 
-	_address := common.HexToAddress(address)
+	return token.BalanceOfAddress(ctx, _address, opts...)
+}
 
-	message, err := abi.Pack("balanceOf", _address)
+// BalanceOfAddress is BalanceOf taking a typed address.
+func (token *RebeccaCoinToken) BalanceOfAddress(ctx context.Context, _address common.Address, opts ...CallOption) (*big.Int, error) {
+	message, err := token.contractABI.Pack("balanceOf", _address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack balanceOf message: %w", err)
 	}
 
+	callOptions := token.newCallOptions(opts)
+
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block number: %w", err)
-	}
-
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	output, err := token.callContract(ctx, callMsg, callOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	var balance *big.Int
-	err = abi.UnpackIntoInterface(&balance, "balanceOf", output)
+	err = token.contractABI.UnpackIntoInterface(&balance, "balanceOf", output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unpack balanceOf: %w", err)
 	}
@@ -814,35 +850,27 @@ func (token *RebeccaCoinToken) BalanceOf(ctx context.Context, address string) (*
 }
 
 // Decimals returns the number of decimals the token uses.
-func (token *RebeccaCoinToken) Decimals(ctx context.Context) (uint8, error) {
-	abi, err := token.getContractABI()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get contract ABI: %w", err)
-	}
-
-	message, err := abi.Pack("decimals")
+func (token *RebeccaCoinToken) Decimals(ctx context.Context, opts ...CallOption) (uint8, error) {
+	message, err := token.contractABI.Pack("decimals")
 	if err != nil {
 		return 0, fmt.Errorf("failed to pack decimals message: %w", err)
 	}
 
+	callOptions := token.newCallOptions(opts)
+
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get block number: %w", err)
-	}
-
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	output, err := token.callContract(ctx, callMsg, callOptions)
 	if err != nil {
 		return 0, fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	var decimals uint8
-	err = abi.UnpackIntoInterface(&decimals, "decimals", output)
+	err = token.contractABI.UnpackIntoInterface(&decimals, "decimals", output)
 	if err != nil {
 		return 0, fmt.Errorf("failed to unpack decimals: %w", err)
 	}
@@ -851,35 +879,27 @@ func (token *RebeccaCoinToken) Decimals(ctx context.Context) (uint8, error) {
 }
 
 // Name returns the name of the token.
-func (token *RebeccaCoinToken) Name(ctx context.Context) (string, error) {
-	abi, err := token.getContractABI()
-	if err != nil {
-		return "", fmt.Errorf("failed to get contract ABI: %w", err)
-	}
-
-	message, err := abi.Pack("name")
+func (token *RebeccaCoinToken) Name(ctx context.Context, opts ...CallOption) (string, error) {
+	message, err := token.contractABI.Pack("name")
 	if err != nil {
 		return "", fmt.Errorf("failed to pack name message: %w", err)
 	}
 
+	callOptions := token.newCallOptions(opts)
+
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get block number: %w", err)
-	}
-
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	output, err := token.callContract(ctx, callMsg, callOptions)
 	if err != nil {
 		return "", fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	var name string
-	err = abi.UnpackIntoInterface(&name, "name", output)
+	err = token.contractABI.UnpackIntoInterface(&name, "name", output)
 	if err != nil {
 		return "", fmt.Errorf("failed to unpack name: %w", err)
 	}
@@ -888,35 +908,27 @@ func (token *RebeccaCoinToken) Name(ctx context.Context) (string, error) {
 }
 
 // Symbol returns the symbol of the token.
-func (token *RebeccaCoinToken) Symbol(ctx context.Context) (string, error) {
-	abi, err := token.getContractABI()
-	if err != nil {
-		return "", fmt.Errorf("failed to get contract ABI: %w", err)
-	}
-
-	message, err := abi.Pack("symbol")
+func (token *RebeccaCoinToken) Symbol(ctx context.Context, opts ...CallOption) (string, error) {
+	message, err := token.contractABI.Pack("symbol")
 	if err != nil {
 		return "", fmt.Errorf("failed to pack symbol message: %w", err)
 	}
 
+	callOptions := token.newCallOptions(opts)
+
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get block number: %w", err)
-	}
-
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	output, err := token.callContract(ctx, callMsg, callOptions)
 	if err != nil {
 		return "", fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	var symbol string
-	err = abi.UnpackIntoInterface(&symbol, "symbol", output)
+	err = token.contractABI.UnpackIntoInterface(&symbol, "symbol", output)
 	if err != nil {
 		return "", fmt.Errorf("failed to unpack symbol: %w", err)
 	}
@@ -925,35 +937,27 @@ func (token *RebeccaCoinToken) Symbol(ctx context.Context) (string, error) {
 }
 
 // TotalSupply returns the total token supply.
-func (token *RebeccaCoinToken) TotalSupply(ctx context.Context) (*big.Int, error) {
-	abi, err := token.getContractABI()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get contract ABI: %w", err)
-	}
-
-	message, err := abi.Pack("totalSupply")
+func (token *RebeccaCoinToken) TotalSupply(ctx context.Context, opts ...CallOption) (*big.Int, error) {
+	message, err := token.contractABI.Pack("totalSupply")
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack totalSupply message: %w", err)
 	}
 
+	callOptions := token.newCallOptions(opts)
+
 	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
+		From: callOptions.from,
 		To:   &token.contractAddress,
 		Data: message,
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block number: %w", err)
-	}
-
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+	output, err := token.callContract(ctx, callMsg, callOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	var totalSupply *big.Int
-	err = abi.UnpackIntoInterface(&totalSupply, "totalSupply", output)
+	err = token.contractABI.UnpackIntoInterface(&totalSupply, "totalSupply", output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unpack totalSupply: %w", err)
 	}
@@ -962,91 +966,58 @@ func (token *RebeccaCoinToken) TotalSupply(ctx context.Context) (*big.Int, error
 }
 
 // Transfer transfers _value amount of tokens to address _to, and MUST fire the Transfer event.
-func (token *RebeccaCoinToken) Transfer(ctx context.Context, to string, amount *big.Int) (bool, error) {
-	abi, err := token.getContractABI()
+func (token *RebeccaCoinToken) Transfer(ctx context.Context, to string, amount *big.Int) (*types.Transaction, error) {
+	_to, err := ParseAddress(to)
 	if err != nil {
-		return false, fmt.Errorf("failed to get contract ABI: %w", err)
+		return nil, err
 	}
 
-	_to := common.HexToAddress(to)
-
-	message, err := abi.Pack("transfer", _to, amount)
-	if err != nil {
-		return false, fmt.Errorf("failed to pack transfer message: %w", err)
-	}
-
-	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
-		To:   &token.contractAddress,
-		Data: message,
-	}
-
-	blockNumber, err := token.client.BlockNumber(ctx)
-	if err != nil {
-		return false, fmt.Errorf("failed to get block number: %w", err)
-	}
+	return token.TransferAddress(ctx, _to, amount)
+}
 
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
+// TransferAddress is Transfer taking a typed address.
+// Transfers to the zero address fail with ErrZeroAddress unless WithAllowZeroAddress is set.
+func (token *RebeccaCoinToken) TransferAddress(ctx context.Context, _to common.Address, amount *big.Int) (*types.Transaction, error) {
+	err := token.checkRecipient(_to)
 	if err != nil {
-		return false, fmt.Errorf("failed to call contract: %w", err)
+		return nil, err
 	}
 
-	var success bool
-	err = abi.UnpackIntoInterface(&success, "transfer", output)
+	tx, err := token.transact(ctx, "transfer", _to, amount)
 	if err != nil {
-		return false, fmt.Errorf("failed to unpack transfer: %w", err)
+		return nil, fmt.Errorf("failed to transact transfer: %w", err)
 	}
 
-	return success, nil
+	return tx, nil
 }
 
 // TransferFrom transfers _value amount of tokens from address _from to address _to, and MUST fire the Transfer event.
-func (token *RebeccaCoinToken) TransferFrom(ctx context.Context, from string, to string, amount *big.Int) (bool, error) {
-	abi, err := token.getContractABI()
-	if err != nil {
-		return false, fmt.Errorf("failed to get contract ABI: %w", err)
-	}
-
-	_from := common.HexToAddress(from)
-	_to := common.HexToAddress(to)
-
-	message, err := abi.Pack("transferFrom", _from, _to, amount)
+func (token *RebeccaCoinToken) TransferFrom(ctx context.Context, from string, to string, amount *big.Int) (*types.Transaction, error) {
+	_from, err := ParseAddress(from)
 	if err != nil {
-		return false, fmt.Errorf("failed to pack transferFrom message: %w", err)
-	}
-
-	callMsg := ethereum.CallMsg{
-		From: token.contractAddress,
-		To:   &token.contractAddress,
-		Data: message,
+		return nil, err
 	}
 
-	blockNumber, err := token.client.BlockNumber(ctx)
+	_to, err := ParseAddress(to)
 	if err != nil {
-		return false, fmt.Errorf("failed to get block number: %w", err)
+		return nil, err
 	}
 
-	output, err := token.client.CallContract(ctx, callMsg, new(big.Int).SetUint64(blockNumber))
-	if err != nil {
-		return false, fmt.Errorf("failed to call contract: %w", err)
-	}
+	return token.TransferFromAddress(ctx, _from, _to, amount)
+}
 
-	var success bool
-	err = abi.UnpackIntoInterface(&success, "transferFrom", output)
+// TransferFromAddress is TransferFrom taking typed addresses.
+// Transfers to the zero address fail with ErrZeroAddress unless WithAllowZeroAddress is set.
+func (token *RebeccaCoinToken) TransferFromAddress(ctx context.Context, _from common.Address, _to common.Address, amount *big.Int) (*types.Transaction, error) {
+	err := token.checkRecipient(_to)
 	if err != nil {
-		return false, fmt.Errorf("failed to unpack transferFrom: %w", err)
+		return nil, err
 	}
 
-	return success, nil
-}
-
-func (token *RebeccaCoinToken) getContractABI() (abi.ABI, error) {
-	contractABIReader := strings.NewReader(token.contractABIJSONSource)
-
-	contractABI, err := abi.JSON(contractABIReader)
+	tx, err := token.transact(ctx, "transferFrom", _from, _to, amount)
 	if err != nil {
-		return abi.ABI{}, fmt.Errorf("failed to parse contract ABI: %w", err)
+		return nil, fmt.Errorf("failed to transact transferFrom: %w", err)
 	}
 
-	return contractABI, nil
+	return tx, nil
 }
@@ -0,0 +1,46 @@
+package rebecca_coin_contract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var benchmarkAddress = common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+// BenchmarkBalanceOfPackParsed packs balanceOf after parsing the ABI on every call, as the token used to.
+func BenchmarkBalanceOfPackParsed(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		contractABI, err := abi.JSON(strings.NewReader(contractABIJSONSource))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		_, err = contractABI.Pack("balanceOf", benchmarkAddress)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBalanceOfPackCached packs balanceOf with the ABI parsed once at construction.
+func BenchmarkBalanceOfPackCached(b *testing.B) {
+	token, err := NewRebeccaCoinToken(nil, benchmarkAddress.Hex())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err = token.contractABI.Pack("balanceOf", benchmarkAddress)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
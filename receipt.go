@@ -0,0 +1,193 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// receiptPollInterval is the delay between receipt lookups in WaitMined.
+const receiptPollInterval = time.Second
+
+type (
+	// Receipt is a transaction receipt with the token events it emitted decoded.
+	Receipt struct {
+		*types.Receipt
+
+		// Transfers and Approvals are the token events emitted by the transaction, in log order.
+		Transfers []*TransferEvent
+		Approvals []*ApprovalEvent
+
+		// Confirmations is the number of blocks on top of and including the receipt's block.
+		Confirmations uint64
+	}
+
+	// ErrTransactionReverted is returned with the receipt of a transaction that was mined but reverted.
+	// Reason is the decoded revert, recovered by replaying the transaction, when the node provides it.
+	ErrTransactionReverted struct {
+		TxHash common.Hash
+		Reason error
+	}
+)
+
+func (err *ErrTransactionReverted) Error() string {
+	if err.Reason == nil {
+		return fmt.Sprintf("transaction %s reverted", err.TxHash.Hex())
+	}
+
+	return fmt.Sprintf("transaction %s reverted: %s", err.TxHash.Hex(), err.Reason)
+}
+
+func (err *ErrTransactionReverted) Unwrap() error {
+	return err.Reason
+}
+
+// WaitMined blocks until the transaction with the given hash is included in a block with at least
+// confirmations blocks on top of and including it, and returns its receipt.
+// Inclusion is re-checked while waiting, so a transaction reorged out of the chain is waited for again.
+// A reverted transaction returns its receipt along with an *ErrTransactionReverted.
+func (token *RebeccaCoinToken) WaitMined(ctx context.Context, txHash common.Hash, confirmations uint64) (*Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := token.confirmedReceipt(ctx, txHash, confirmations)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, &ErrTransactionReverted{
+					TxHash: txHash,
+					Reason: token.replayRevert(ctx, receipt.Receipt),
+				}
+			}
+
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// confirmedReceipt returns the receipt of the transaction if it is in the canonical chain with enough
+// confirmations, or nil if it has to be waited for.
+func (token *RebeccaCoinToken) confirmedReceipt(ctx context.Context, txHash common.Hash, confirmations uint64) (*Receipt, error) {
+	receipt, err := token.client.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	latest, err := token.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	number := receipt.BlockNumber.Uint64()
+	if latest < number {
+		return nil, nil
+	}
+
+	depth := latest - number + 1
+	if depth < confirmations {
+		return nil, nil
+	}
+
+	header, err := token.client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header %d: %w", number, err)
+	}
+	if header.Hash() != receipt.BlockHash {
+		return nil, nil
+	}
+
+	return token.newReceipt(receipt, depth)
+}
+
+// newReceipt wraps receipt and decodes the token events it contains.
+func (token *RebeccaCoinToken) newReceipt(receipt *types.Receipt, confirmations uint64) (*Receipt, error) {
+	result := &Receipt{
+		Receipt:       receipt,
+		Confirmations: confirmations,
+	}
+
+	transferID := token.contractABI.Events["Transfer"].ID
+	approvalID := token.contractABI.Events["Approval"].ID
+
+	for _, log := range receipt.Logs {
+		if log.Address != token.contractAddress || len(log.Topics) == 0 {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case transferID:
+			event, err := token.ParseTransfer(*log)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Transfers = append(result.Transfers, event)
+		case approvalID:
+			event, err := token.ParseApproval(*log)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Approvals = append(result.Approvals, event)
+		}
+	}
+
+	return result, nil
+}
+
+// replayRevert re-executes the transaction on the state before its block and returns the decoded revert,
+// or nil if it cannot be recovered.
+func (token *RebeccaCoinToken) replayRevert(ctx context.Context, receipt *types.Receipt) error {
+	tx, _, err := token.client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return nil
+	}
+
+	header, err := token.client.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil
+	}
+
+	from, err := token.client.TransactionSender(ctx, tx, receipt.BlockHash, receipt.TransactionIndex)
+	if err != nil {
+		return nil
+	}
+
+	callMsg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	_, err = token.client.CallContractAtHash(ctx, callMsg, header.ParentHash)
+	if err == nil {
+		return nil
+	}
+
+	// Replays also fail for reasons unrelated to the revert, such as pruned state or timeouts.
+	data, ok := revertData(err)
+	if !ok {
+		return nil
+	}
+
+	return token.DecodeRevert(data)
+}
@@ -0,0 +1,325 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// defaultStuckTimeout is how long a transaction may stay pending before it is replaced.
+	defaultStuckTimeout = 5 * time.Minute
+
+	// minReplacementBump is the fee increase, in percent, nodes require to accept a replacement
+	// transaction with the same nonce.
+	minReplacementBump = 10
+)
+
+type (
+	// TxManagerOpts configures a transaction lifecycle manager.
+	TxManagerOpts struct {
+		// Timeout is how long a transaction may stay pending before it is replaced.
+		Timeout time.Duration
+
+		// PollInterval is the delay between checks of the pending transactions in Run.
+		PollInterval time.Duration
+
+		// BumpPercent is the fee increase of a replacement. Values below minReplacementBump are raised to it.
+		BumpPercent uint64
+
+		// MaxReplacements is the number of fee bumps after which a stuck transaction is cancelled
+		// instead. Zero never cancels automatically.
+		MaxReplacements int
+
+		// OnReplaced is called when a stuck transaction is replaced or cancelled.
+		OnReplaced func(stuck *types.Transaction, replacement *types.Transaction)
+
+		// OnMined is called when one of the versions of a tracked transaction is mined.
+		OnMined func(tx *types.Transaction, receipt *types.Receipt)
+
+		// OnError is called with errors Run recovers from by retrying.
+		OnError func(err error)
+	}
+
+	// TxManager monitors pending transactions sent by the token's signer and replaces the ones stuck in
+	// the mempool with higher fees, or cancels them with a zero-value self-send at the same nonce.
+	TxManager struct {
+		token   *RebeccaCoinToken
+		opts    TxManagerOpts
+		lock    sync.Mutex
+		pending map[uint64]*trackedTx
+	}
+
+	// trackedTx is a pending nonce and every version of the transaction sent with it.
+	trackedTx struct {
+		versions     []*types.Transaction
+		sentAt       time.Time
+		replacements int
+	}
+)
+
+// NewTxManager creates a new TxManager instance.
+func (token *RebeccaCoinToken) NewTxManager(opts *TxManagerOpts) *TxManager {
+	var managerOpts TxManagerOpts
+	if opts != nil {
+		managerOpts = *opts
+	}
+	if managerOpts.Timeout <= 0 {
+		managerOpts.Timeout = defaultStuckTimeout
+	}
+	if managerOpts.PollInterval <= 0 {
+		managerOpts.PollInterval = defaultPollInterval
+	}
+	if managerOpts.BumpPercent < minReplacementBump {
+		managerOpts.BumpPercent = minReplacementBump
+	}
+
+	return &TxManager{
+		token:   token,
+		opts:    managerOpts,
+		pending: make(map[uint64]*trackedTx),
+	}
+}
+
+// Track starts monitoring a transaction sent by the token's signer.
+func (manager *TxManager) Track(tx *types.Transaction) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	tracked, ok := manager.pending[tx.Nonce()]
+	if !ok {
+		tracked = &trackedTx{}
+		manager.pending[tx.Nonce()] = tracked
+	}
+
+	tracked.versions = append(tracked.versions, tx)
+	tracked.sentAt = time.Now()
+}
+
+// Pending returns the latest version of every tracked transaction, ordered by nonce.
+func (manager *TxManager) Pending() []*types.Transaction {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	txs := make([]*types.Transaction, 0, len(manager.pending))
+	for _, tracked := range manager.pending {
+		txs = append(txs, tracked.latest())
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+
+	return txs
+}
+
+// Run checks the tracked transactions every PollInterval until ctx is done, forgetting mined ones and
+// replacing the ones pending for longer than Timeout.
+func (manager *TxManager) Run(ctx context.Context) error {
+	for {
+		err := manager.Check(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if manager.opts.OnError != nil {
+				manager.opts.OnError(err)
+			}
+		}
+
+		if !sleepContext(ctx, manager.opts.PollInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Check forgets the tracked transactions whose nonce was mined and replaces, or past MaxReplacements
+// cancels, the ones pending for longer than Timeout.
+func (manager *TxManager) Check(ctx context.Context) error {
+	if manager.token.signer == nil {
+		return ErrNoSigner
+	}
+
+	minedNonce, err := manager.token.client.NonceAt(ctx, manager.token.signer.Address(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	var errs []error
+	for _, tx := range manager.Pending() {
+		if tx.Nonce() < minedNonce {
+			errs = append(errs, manager.settle(ctx, tx.Nonce()))
+			continue
+		}
+
+		manager.lock.Lock()
+		tracked, ok := manager.pending[tx.Nonce()]
+		stuck := ok && time.Since(tracked.sentAt) >= manager.opts.Timeout
+		cancel := ok && manager.opts.MaxReplacements > 0 && tracked.replacements >= manager.opts.MaxReplacements
+		manager.lock.Unlock()
+
+		if !stuck {
+			continue
+		}
+
+		if cancel {
+			_, err = manager.Cancel(ctx, tx)
+		} else {
+			_, err = manager.Replace(ctx, tx)
+		}
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Replace resends the transaction with the same nonce and call, with fees bumped by at least BumpPercent.
+func (manager *TxManager) Replace(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return manager.replace(ctx, tx, false)
+}
+
+// Cancel replaces the transaction with a zero-value transfer to the signer itself at the same nonce,
+// with fees bumped by at least BumpPercent.
+func (manager *TxManager) Cancel(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return manager.replace(ctx, tx, true)
+}
+
+// replace sends a fee-bumped replacement, or cancellation, of tx and tracks it.
+func (manager *TxManager) replace(ctx context.Context, tx *types.Transaction, cancel bool) (*types.Transaction, error) {
+	if manager.token.signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	from := manager.token.signer.Address()
+
+	chainID, err := manager.token.client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	header, err := manager.token.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	fees, err := manager.token.fees(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	to := tx.To()
+	value := tx.Value()
+	data := tx.Data()
+	gas := tx.Gas()
+	if cancel {
+		to = &from
+		value = new(big.Int)
+		data = nil
+		gas = params.TxGas
+	}
+
+	var txData types.TxData
+	if tx.Type() == types.LegacyTxType {
+		txData = &types.LegacyTx{
+			GasPrice: maxBig(manager.bump(tx.GasPrice()), fees.GasPrice),
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	} else {
+		txData = &types.DynamicFeeTx{
+			ChainID:   chainID,
+			GasTipCap: maxBig(manager.bump(tx.GasTipCap()), fees.GasTipCap),
+			GasFeeCap: maxBig(manager.bump(tx.GasFeeCap()), fees.GasFeeCap),
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+	}
+
+	feePerGas := types.NewTx(txData).GasFeeCap()
+	if manager.token.maxFeePerGas != nil && feePerGas.Cmp(manager.token.maxFeePerGas) > 0 {
+		return nil, &ErrMaxFeeExceeded{FeePerGas: feePerGas, MaxFeePerGas: manager.token.maxFeePerGas}
+	}
+
+	replacement, err := manager.token.signAndSend(ctx, txData, tx.Nonce(), chainID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.lock.Lock()
+	tracked, ok := manager.pending[tx.Nonce()]
+	if !ok {
+		tracked = &trackedTx{versions: []*types.Transaction{tx}}
+		manager.pending[tx.Nonce()] = tracked
+	}
+	tracked.versions = append(tracked.versions, replacement)
+	tracked.sentAt = time.Now()
+	tracked.replacements++
+	manager.lock.Unlock()
+
+	if manager.opts.OnReplaced != nil {
+		manager.opts.OnReplaced(tx, replacement)
+	}
+
+	return replacement, nil
+}
+
+// settle forgets a mined nonce and reports which of its versions was mined.
+func (manager *TxManager) settle(ctx context.Context, nonce uint64) error {
+	manager.lock.Lock()
+	tracked, ok := manager.pending[nonce]
+	delete(manager.pending, nonce)
+	manager.lock.Unlock()
+
+	if !ok || manager.opts.OnMined == nil {
+		return nil
+	}
+
+	for _, tx := range tracked.versions {
+		receipt, err := manager.token.client.TransactionReceipt(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get transaction receipt: %w", err)
+		}
+
+		manager.opts.OnMined(tx, receipt)
+		return nil
+	}
+
+	return nil
+}
+
+// bump raises fee by BumpPercent, rounding up.
+func (manager *TxManager) bump(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+manager.opts.BumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// latest returns the most recently sent version.
+func (tracked *trackedTx) latest() *types.Transaction {
+	return tracked.versions[len(tracked.versions)-1]
+}
+
+// maxBig returns the larger of a and b, ignoring nil values.
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if b == nil || (a != nil && a.Cmp(b) >= 0) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,369 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// ClefSignTransactionMethod is the JSON-RPC method Clef exposes for signing transactions.
+	ClefSignTransactionMethod = "account_signTransaction"
+
+	// NodeSignTransactionMethod is the JSON-RPC method nodes with unlocked accounts expose for signing transactions.
+	NodeSignTransactionMethod = "eth_signTransaction"
+
+	// clefSignTypedDataMethod is the JSON-RPC method Clef exposes for signing EIP-712 typed data.
+	clefSignTypedDataMethod = "account_signTypedData"
+
+	// nodeSignTypedDataMethod is the JSON-RPC method nodes expose for signing EIP-712 typed data.
+	nodeSignTypedDataMethod = "eth_signTypedData_v4"
+)
+
+type (
+	// Signer signs transactions on behalf of a single account.
+	Signer interface {
+		// Address returns the account the signer signs for.
+		Address() common.Address
+
+		// SignTx signs the transaction for the given chain.
+		SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	}
+
+	// TypedDataSigner signs EIP-712 typed data on behalf of a single account.
+	TypedDataSigner interface {
+		// Address returns the account the signer signs for.
+		Address() common.Address
+
+		// SignTypedData returns the 65-byte [R || S || V] signature of the typed data.
+		SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+	}
+
+	// PrivateKeySigner signs transactions with an in-memory ECDSA key.
+	PrivateKeySigner struct {
+		key     *ecdsa.PrivateKey
+		address common.Address
+	}
+
+	// KeystoreSigner signs transactions with a go-ethereum encrypted keystore file.
+	// By default the key is decrypted for every signature and wiped right after. With the standard
+	// scrypt parameters a decryption takes around a second and 256MB of memory, so high-volume senders
+	// should call Unlock to keep the key decrypted for a bounded time.
+	KeystoreSigner struct {
+		keyJSON    []byte
+		passphrase string
+		address    common.Address
+
+		lock        sync.Mutex
+		unlocked    *ecdsa.PrivateKey
+		unlockTimer *time.Timer
+	}
+
+	// RemoteSigner signs transactions through an external JSON-RPC signer such as Clef.
+	RemoteSigner struct {
+		client  *rpc.Client
+		address common.Address
+		method  string
+	}
+
+	// TransactOptsSigner adapts a bind.TransactOpts to the Signer interface.
+	TransactOptsSigner struct {
+		opts *bind.TransactOpts
+	}
+
+	// signTransactionResult is the response of the remote sign methods.
+	signTransactionResult struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+)
+
+// NewPrivateKeySigner creates a new PrivateKeySigner instance.
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// Address returns the account the signer signs for.
+func (signer *PrivateKeySigner) Address() common.Address {
+	return signer.address
+}
+
+// SignTx signs the transaction for the given chain.
+func (signer *PrivateKeySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), signer.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTypedData returns the 65-byte [R || S || V] signature of the typed data.
+func (signer *PrivateKeySigner) SignTypedData(_ context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return signTypedData(typedData, signer.key)
+}
+
+// NewKeystoreSigner creates a new KeystoreSigner instance from the keystore file at path.
+// The passphrase is checked once by decrypting the key, which is then wiped.
+func NewKeystoreSigner(path string, passphrase string) (*KeystoreSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+	defer wipeKey(key.PrivateKey)
+
+	return &KeystoreSigner{
+		keyJSON:    keyJSON,
+		passphrase: passphrase,
+		address:    key.Address,
+	}, nil
+}
+
+// Address returns the account the signer signs for.
+func (signer *KeystoreSigner) Address() common.Address {
+	return signer.address
+}
+
+// Unlock decrypts the key once and keeps it in memory for duration, after which it is wiped.
+// Calling Unlock again restarts the duration.
+func (signer *KeystoreSigner) Unlock(duration time.Duration) error {
+	key, err := keystore.DecryptKey(signer.keyJSON, signer.passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+
+	signer.lock.Lock()
+	defer signer.lock.Unlock()
+
+	signer.lockKey()
+
+	signer.unlocked = key.PrivateKey
+	signer.unlockTimer = time.AfterFunc(duration, func() {
+		signer.lock.Lock()
+		defer signer.lock.Unlock()
+
+		// A later Unlock may have replaced the key while this timer was firing.
+		if signer.unlocked == key.PrivateKey {
+			signer.lockKey()
+		}
+	})
+
+	return nil
+}
+
+// Lock wipes the key kept in memory by Unlock.
+func (signer *KeystoreSigner) Lock() {
+	signer.lock.Lock()
+	defer signer.lock.Unlock()
+
+	signer.lockKey()
+}
+
+// SignTx signs the transaction for the given chain.
+func (signer *KeystoreSigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	key, release, err := signer.key()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTypedData returns the 65-byte [R || S || V] signature of the typed data.
+func (signer *KeystoreSigner) SignTypedData(_ context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	key, release, err := signer.key()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return signTypedData(typedData, key)
+}
+
+// key returns the unlocked key, or decrypts a temporary one, and a function to call when done with it.
+func (signer *KeystoreSigner) key() (*ecdsa.PrivateKey, func(), error) {
+	signer.lock.Lock()
+	if signer.unlocked != nil {
+		return signer.unlocked, signer.lock.Unlock, nil
+	}
+	signer.lock.Unlock()
+
+	key, err := keystore.DecryptKey(signer.keyJSON, signer.passphrase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+
+	return key.PrivateKey, func() { wipeKey(key.PrivateKey) }, nil
+}
+
+// lockKey wipes the unlocked key. The caller must hold the lock.
+func (signer *KeystoreSigner) lockKey() {
+	if signer.unlockTimer != nil {
+		signer.unlockTimer.Stop()
+		signer.unlockTimer = nil
+	}
+
+	if signer.unlocked != nil {
+		wipeKey(signer.unlocked)
+		signer.unlocked = nil
+	}
+}
+
+// NewRemoteSigner creates a new RemoteSigner instance.
+// The method is usually ClefSignTransactionMethod or NodeSignTransactionMethod.
+func NewRemoteSigner(client *rpc.Client, address common.Address, method string) *RemoteSigner {
+	return &RemoteSigner{
+		client:  client,
+		address: address,
+		method:  method,
+	}
+}
+
+// DialRemoteSigner connects to the signer at endpoint and creates a new RemoteSigner instance.
+func DialRemoteSigner(ctx context.Context, endpoint string, address common.Address, method string) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer: %w", err)
+	}
+
+	return NewRemoteSigner(client, address, method), nil
+}
+
+// Address returns the account the signer signs for.
+func (signer *RemoteSigner) Address() common.Address {
+	return signer.address
+}
+
+// SignTx signs the transaction for the given chain.
+func (signer *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(signer.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if to := tx.To(); to != nil {
+		_to := common.NewMixedcaseAddress(*to)
+		args.To = &_to
+	}
+
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	params := []any{args}
+	if signer.method == ClefSignTransactionMethod {
+		// Clef takes an optional method selector used for calldata validation.
+		params = append(params, nil)
+	}
+
+	var result signTransactionResult
+	err := signer.client.CallContext(ctx, &result, signer.method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", signer.method, err)
+	}
+
+	signedTx := new(types.Transaction)
+	err = signedTx.UnmarshalBinary(result.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTypedData returns the 65-byte [R || S || V] signature of the typed data.
+func (signer *RemoteSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	method := nodeSignTypedDataMethod
+	if signer.method == ClefSignTransactionMethod {
+		method = clefSignTypedDataMethod
+	}
+
+	var signature hexutil.Bytes
+	err := signer.client.CallContext(ctx, &signature, method, common.NewMixedcaseAddress(signer.address), typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	return signature, nil
+}
+
+// NewTransactOptsSigner creates a new TransactOptsSigner instance.
+func NewTransactOptsSigner(opts *bind.TransactOpts) *TransactOptsSigner {
+	return &TransactOptsSigner{
+		opts: opts,
+	}
+}
+
+// Address returns the account the signer signs for.
+func (signer *TransactOptsSigner) Address() common.Address {
+	return signer.opts.From
+}
+
+// SignTx signs the transaction with the transactor's signer function, which already carries the chain ID.
+func (signer *TransactOptsSigner) SignTx(_ context.Context, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	signedTx, err := signer.opts.Signer(signer.opts.From, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// signTypedData hashes the typed data and signs it with key.
+func signTypedData(typedData apitypes.TypedData, key *ecdsa.PrivateKey) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	signature, err := crypto.Sign(digest, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+// wipeKey zeroes the private key material.
+func wipeKey(key *ecdsa.PrivateKey) {
+	bits := key.D.Bits()
+	for i := range bits {
+		bits[i] = 0
+	}
+}
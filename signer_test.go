@@ -0,0 +1,113 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// testSignerService is a local stand-in for Clef and for nodes with unlocked accounts.
+type testSignerService struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+}
+
+// SignTransaction serves account_signTransaction and eth_signTransaction.
+func (service *testSignerService) SignTransaction(args apitypes.SendTxArgs, methodSelector *string) (*signTransactionResult, error) {
+	signedTx, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(service.chainID), service.key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{Raw: raw}, nil
+}
+
+func TestRemoteSignerSignTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	server := rpc.NewServer()
+	defer server.Stop()
+
+	service := &testSignerService{key: key, chainID: chainID}
+	for _, namespace := range []string{"account", "eth"} {
+		err = server.RegisterName(namespace, service)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	txs := map[string]*types.Transaction{
+		"legacy": types.NewTx(&types.LegacyTx{
+			Nonce:    7,
+			GasPrice: big.NewInt(1_000_000_000),
+			Gas:      60_000,
+			To:       &to,
+			Data:     []byte{0xa9, 0x05, 0x9c, 0xbb},
+		}),
+		"dynamic fee": types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     8,
+			GasTipCap: big.NewInt(1_000_000_000),
+			GasFeeCap: big.NewInt(30_000_000_000),
+			Gas:       60_000,
+			To:        &to,
+			Data:      []byte{0x09, 0x5e, 0xa7, 0xb3},
+		}),
+	}
+
+	for _, method := range []string{ClefSignTransactionMethod, NodeSignTransactionMethod} {
+		for name, tx := range txs {
+			t.Run(method+"/"+name, func(t *testing.T) {
+				signer := NewRemoteSigner(client, address, method)
+
+				signedTx, err := signer.SignTx(context.Background(), tx, chainID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if sender != address {
+					t.Errorf("signed by %s, want %s", sender.Hex(), address.Hex())
+				}
+
+				if signedTx.Type() != tx.Type() {
+					t.Errorf("type %d, want %d", signedTx.Type(), tx.Type())
+				}
+				if signedTx.Nonce() != tx.Nonce() || signedTx.Gas() != tx.Gas() || *signedTx.To() != *tx.To() {
+					t.Errorf("nonce, gas or recipient changed: got %d/%d/%s", signedTx.Nonce(), signedTx.Gas(), signedTx.To().Hex())
+				}
+				if signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 || signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0 {
+					t.Errorf("fees changed: got %s/%s", signedTx.GasFeeCap(), signedTx.GasTipCap())
+				}
+				if string(signedTx.Data()) != string(tx.Data()) {
+					t.Errorf("data %x, want %x", signedTx.Data(), tx.Data())
+				}
+			})
+		}
+	}
+}
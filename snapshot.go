@@ -0,0 +1,160 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrSnapshotReorged is returned when the snapshot block is no longer part of the canonical chain.
+	ErrSnapshotReorged = errors.New("snapshot block is no longer canonical")
+
+	// ErrSnapshotReadOnly is returned by the state-changing methods of a TokenSnapshot.
+	ErrSnapshotReadOnly = errors.New("snapshot is read-only")
+)
+
+// TokenSnapshot is a read-only ERC20Token bound to a single block, so that every read is mutually consistent.
+type TokenSnapshot struct {
+	token       *RebeccaCoinToken
+	blockHash   common.Hash
+	blockNumber uint64
+}
+
+var _ ERC20Token = (*TokenSnapshot)(nil)
+
+// Snapshot returns a view of the token bound to the latest block.
+func (token *RebeccaCoinToken) Snapshot(ctx context.Context) (*TokenSnapshot, error) {
+	return token.AtBlock(ctx, nil)
+}
+
+// AtBlock returns a view of the token bound to the block with the given number, or the latest block if nil.
+func (token *RebeccaCoinToken) AtBlock(ctx context.Context, number *big.Int) (*TokenSnapshot, error) {
+	header, err := token.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	return &TokenSnapshot{
+		token:       token,
+		blockHash:   header.Hash(),
+		blockNumber: header.Number.Uint64(),
+	}, nil
+}
+
+// BlockHash returns the hash of the block the snapshot is bound to.
+func (snapshot *TokenSnapshot) BlockHash() common.Hash {
+	return snapshot.blockHash
+}
+
+// BlockNumber returns the number of the block the snapshot is bound to.
+func (snapshot *TokenSnapshot) BlockNumber() uint64 {
+	return snapshot.blockNumber
+}
+
+// Verify returns ErrSnapshotReorged if the snapshot block is no longer canonical.
+func (snapshot *TokenSnapshot) Verify(ctx context.Context) error {
+	header, err := snapshot.token.client.HeaderByNumber(ctx, new(big.Int).SetUint64(snapshot.blockNumber))
+	if err != nil {
+		return fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	if header.Hash() != snapshot.blockHash {
+		return fmt.Errorf("%w: block %d is now %s", ErrSnapshotReorged, snapshot.blockNumber, header.Hash().Hex())
+	}
+
+	return nil
+}
+
+// Allowance returns the amount which _spender is still allowed to withdraw from _owner.
+func (snapshot *TokenSnapshot) Allowance(ctx context.Context, owner, spender string, opts ...CallOption) (*big.Int, error) {
+	allowance, err := snapshot.token.Allowance(ctx, owner, spender, snapshot.callOptions(opts)...)
+	if err != nil {
+		return nil, snapshot.checkReorg(ctx, err)
+	}
+
+	return allowance, nil
+}
+
+// BalanceOf returns the account balance of another account with address _owner.
+func (snapshot *TokenSnapshot) BalanceOf(ctx context.Context, address string, opts ...CallOption) (*big.Int, error) {
+	balance, err := snapshot.token.BalanceOf(ctx, address, snapshot.callOptions(opts)...)
+	if err != nil {
+		return nil, snapshot.checkReorg(ctx, err)
+	}
+
+	return balance, nil
+}
+
+// Decimals returns the number of decimals the token uses.
+func (snapshot *TokenSnapshot) Decimals(ctx context.Context, opts ...CallOption) (uint8, error) {
+	decimals, err := snapshot.token.Decimals(ctx, snapshot.callOptions(opts)...)
+	if err != nil {
+		return 0, snapshot.checkReorg(ctx, err)
+	}
+
+	return decimals, nil
+}
+
+// Name returns the name of the token.
+func (snapshot *TokenSnapshot) Name(ctx context.Context, opts ...CallOption) (string, error) {
+	name, err := snapshot.token.Name(ctx, snapshot.callOptions(opts)...)
+	if err != nil {
+		return "", snapshot.checkReorg(ctx, err)
+	}
+
+	return name, nil
+}
+
+// Symbol returns the symbol of the token.
+func (snapshot *TokenSnapshot) Symbol(ctx context.Context, opts ...CallOption) (string, error) {
+	symbol, err := snapshot.token.Symbol(ctx, snapshot.callOptions(opts)...)
+	if err != nil {
+		return "", snapshot.checkReorg(ctx, err)
+	}
+
+	return symbol, nil
+}
+
+// TotalSupply returns the total token supply.
+func (snapshot *TokenSnapshot) TotalSupply(ctx context.Context, opts ...CallOption) (*big.Int, error) {
+	totalSupply, err := snapshot.token.TotalSupply(ctx, snapshot.callOptions(opts)...)
+	if err != nil {
+		return nil, snapshot.checkReorg(ctx, err)
+	}
+
+	return totalSupply, nil
+}
+
+// Approve always fails with ErrSnapshotReadOnly.
+func (snapshot *TokenSnapshot) Approve(context.Context, string, *big.Int) (*types.Transaction, error) {
+	return nil, ErrSnapshotReadOnly
+}
+
+// Transfer always fails with ErrSnapshotReadOnly.
+func (snapshot *TokenSnapshot) Transfer(context.Context, string, *big.Int) (*types.Transaction, error) {
+	return nil, ErrSnapshotReadOnly
+}
+
+// TransferFrom always fails with ErrSnapshotReadOnly.
+func (snapshot *TokenSnapshot) TransferFrom(context.Context, string, string, *big.Int) (*types.Transaction, error) {
+	return nil, ErrSnapshotReadOnly
+}
+
+// callOptions pins the call to the snapshot block; it is applied last so callers cannot move the block.
+func (snapshot *TokenSnapshot) callOptions(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], AtCanonicalBlockHash(snapshot.blockHash))
+}
+
+// checkReorg reports ErrSnapshotReorged instead of err when the failure was caused by a reorg.
+func (snapshot *TokenSnapshot) checkReorg(ctx context.Context, err error) error {
+	if verifyErr := snapshot.Verify(ctx); errors.Is(verifyErr, ErrSnapshotReorged) {
+		return verifyErr
+	}
+
+	return err
+}
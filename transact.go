@@ -0,0 +1,153 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNoSigner is returned by state-changing methods when the token has no signer configured.
+var ErrNoSigner = errors.New("no signer configured")
+
+// transact packs the token method call, signs it with the configured signer and broadcasts it.
+func (token *RebeccaCoinToken) transact(ctx context.Context, method string, args ...any) (*types.Transaction, error) {
+	message, err := token.contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s message: %w", method, err)
+	}
+
+	return token.sendTransaction(ctx, token.contractAddress, message)
+}
+
+// sendTransaction signs a call of the contract at to with the configured signer and broadcasts it.
+func (token *RebeccaCoinToken) sendTransaction(ctx context.Context, to common.Address, message []byte) (*types.Transaction, error) {
+	if token.signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	from := token.signer.Address()
+
+	chainID, err := token.client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	header, err := token.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	callMsg := ethereum.CallMsg{
+		From: from,
+		To:   &to,
+		Data: message,
+	}
+
+	fees, err := token.fees(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	callMsg.GasPrice = fees.GasPrice
+	callMsg.GasTipCap = fees.GasTipCap
+	callMsg.GasFeeCap = fees.GasFeeCap
+
+	gas, err := token.client.EstimateGas(ctx, callMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", token.decodeError(err))
+	}
+
+	gas = token.applyGasMultiplier(gas)
+
+	var txData types.TxData
+	if fees.GasPrice != nil {
+		txData = &types.LegacyTx{
+			GasPrice: fees.GasPrice,
+			Gas:      gas,
+			To:       &to,
+			Data:     message,
+		}
+	} else {
+		txData = &types.DynamicFeeTx{
+			ChainID:   chainID,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gas,
+			To:        &to,
+			Data:      message,
+		}
+	}
+
+	if token.nonceManager == nil {
+		nonce, err := token.client.PendingNonceAt(ctx, from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending nonce: %w", err)
+		}
+
+		return token.signAndSend(ctx, txData, nonce, chainID, from)
+	}
+
+	for resynced := false; ; resynced = true {
+		nonce, err := token.nonceManager.Reserve(ctx, from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve nonce: %w", err)
+		}
+
+		signedTx, err := token.signAndSend(ctx, txData, nonce, chainID, from)
+		if err == nil {
+			// The transaction is out, so failing to persist the release must not fail the call; on restart
+			// the node's pending nonce covers it.
+			err = token.nonceManager.Release(from, nonce, true)
+			if err != nil {
+				token.nonceManager.reportError(fmt.Errorf("failed to release nonce %d of sent transaction %s: %w", nonce, signedTx.Hash().Hex(), err))
+			}
+
+			return signedTx, nil
+		}
+
+		if resynced || !isNonceError(err) {
+			return nil, errors.Join(err, token.nonceManager.Release(from, nonce, false))
+		}
+
+		// The nonce is used by a transaction the manager does not know about, so it is not handed out again.
+		err = errors.Join(token.nonceManager.Release(from, nonce, true), token.nonceManager.Resync(ctx, from))
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// signAndSend sets the nonce of txData, signs the transaction with the configured signer and broadcasts it.
+func (token *RebeccaCoinToken) signAndSend(ctx context.Context, txData types.TxData, nonce uint64, chainID *big.Int, from common.Address) (*types.Transaction, error) {
+	switch txData := txData.(type) {
+	case *types.LegacyTx:
+		txData.Nonce = nonce
+	case *types.DynamicFeeTx:
+		txData.Nonce = nonce
+	}
+
+	signedTx, err := token.signer.SignTx(ctx, types.NewTx(txData), chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+	if sender != from {
+		return nil, fmt.Errorf("transaction signed by %s, expected %s", sender.Hex(), from.Hex())
+	}
+
+	err = token.client.SendTransaction(ctx, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
@@ -0,0 +1,395 @@
+package rebecca_coin_contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultPollInterval is the delay between polls when the client cannot subscribe to logs.
+	defaultPollInterval = 4 * time.Second
+
+	// defaultReorgDepth is the number of recent blocks re-checked for reorged logs.
+	defaultReorgDepth = 32
+
+	// minRetryDelay and maxRetryDelay bound the backoff after a failed poll or dropped subscription.
+	minRetryDelay = time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+type (
+	// WatchOpts configures an event watch.
+	WatchOpts struct {
+		// StartBlock is the first block to deliver events from. Nil starts after the latest block.
+		StartBlock *uint64
+
+		// PollInterval is the delay between polls when the client does not support subscriptions.
+		PollInterval time.Duration
+
+		// ReorgDepth is the number of recent blocks re-checked for reorged events after a poll or a reconnect.
+		ReorgDepth uint64
+
+		// OnError is called with errors the watch recovers from by retrying.
+		OnError func(err error)
+	}
+
+	// Subscription streams decoded events until it is unsubscribed or its context is done.
+	// Events of logs reorged out of the chain are delivered again with Removed set.
+	Subscription[T any] struct {
+		events chan T
+		cancel context.CancelFunc
+		done   chan struct{}
+		once   sync.Once
+	}
+
+	// logWatcher delivers the logs matching a query exactly once, resuming after errors and
+	// reporting reorged logs as removed.
+	logWatcher struct {
+		token     *RebeccaCoinToken
+		query     ethereum.FilterQuery
+		opts      WatchOpts
+		start     uint64
+		next      uint64
+		polling   bool
+		delivered map[logKey]types.Log
+		deliver   func(ctx context.Context, log types.Log) bool
+	}
+
+	// logKey identifies a log in a specific block.
+	logKey struct {
+		blockHash common.Hash
+		txHash    common.Hash
+		index     uint
+	}
+)
+
+// Events returns the channel events are delivered on. It is closed when the watch stops.
+func (subscription *Subscription[T]) Events() <-chan T {
+	return subscription.events
+}
+
+// Unsubscribe stops the watch and waits for it to exit.
+func (subscription *Subscription[T]) Unsubscribe() {
+	subscription.once.Do(subscription.cancel)
+	<-subscription.done
+}
+
+// WatchTransfers streams Transfer events. Empty from or to lists match any address.
+func (token *RebeccaCoinToken) WatchTransfers(ctx context.Context, opts *WatchOpts, from []string, to []string) (*Subscription[*TransferEvent], error) {
+	topics, err := addressTopics(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	query := token.eventQuery(0, nil, "Transfer", topics...)
+
+	return watchEvents(ctx, token, opts, query, token.ParseTransfer)
+}
+
+// WatchApprovals streams Approval events. Empty owner or spender lists match any address.
+func (token *RebeccaCoinToken) WatchApprovals(ctx context.Context, opts *WatchOpts, owner []string, spender []string) (*Subscription[*ApprovalEvent], error) {
+	topics, err := addressTopics(owner, spender)
+	if err != nil {
+		return nil, err
+	}
+
+	query := token.eventQuery(0, nil, "Approval", topics...)
+
+	return watchEvents(ctx, token, opts, query, token.ParseApproval)
+}
+
+// WatchAuthorityUpdated streams AuthorityUpdated events.
+func (token *RebeccaCoinToken) WatchAuthorityUpdated(ctx context.Context, opts *WatchOpts) (*Subscription[*AuthorityUpdatedEvent], error) {
+	query := token.eventQuery(0, nil, "AuthorityUpdated")
+
+	return watchEvents(ctx, token, opts, query, token.ParseAuthorityUpdated)
+}
+
+// watchEvents starts a log watcher for the query and decodes its logs with parse.
+func watchEvents[T any](ctx context.Context, token *RebeccaCoinToken, opts *WatchOpts, query ethereum.FilterQuery, parse func(types.Log) (T, error)) (*Subscription[T], error) {
+	watcher, err := newLogWatcher(ctx, token, opts, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	subscription := &Subscription[T]{
+		events: make(chan T),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	watcher.deliver = func(ctx context.Context, log types.Log) bool {
+		event, err := parse(log)
+		if err != nil {
+			watcher.reportError(err)
+			return true
+		}
+
+		select {
+		case subscription.events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(subscription.done)
+		defer close(subscription.events)
+
+		watcher.run(ctx)
+	}()
+
+	return subscription, nil
+}
+
+// newLogWatcher creates a logWatcher starting at opts.StartBlock or after the latest block.
+func newLogWatcher(ctx context.Context, token *RebeccaCoinToken, opts *WatchOpts, query ethereum.FilterQuery) (*logWatcher, error) {
+	watcher := &logWatcher{
+		token:     token,
+		query:     query,
+		delivered: make(map[logKey]types.Log),
+	}
+
+	if opts != nil {
+		watcher.opts = *opts
+	}
+	if watcher.opts.PollInterval <= 0 {
+		watcher.opts.PollInterval = defaultPollInterval
+	}
+	if watcher.opts.ReorgDepth == 0 {
+		watcher.opts.ReorgDepth = defaultReorgDepth
+	}
+
+	if watcher.opts.StartBlock != nil {
+		watcher.start = *watcher.opts.StartBlock
+	} else {
+		head, err := token.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block number: %w", err)
+		}
+
+		watcher.start = head + 1
+	}
+
+	watcher.next = watcher.start
+
+	return watcher, nil
+}
+
+// run delivers logs until ctx is done, over a subscription when the client supports it and by polling otherwise.
+func (watcher *logWatcher) run(ctx context.Context) {
+	retryDelay := minRetryDelay
+
+	for ctx.Err() == nil {
+		err := watcher.sync(ctx)
+		if err == nil && !watcher.polling {
+			err = watcher.subscribe(ctx)
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			watcher.reportError(err)
+			if !sleepContext(ctx, retryDelay) {
+				return
+			}
+
+			retryDelay = min(retryDelay*2, maxRetryDelay)
+			continue
+		}
+
+		retryDelay = minRetryDelay
+
+		if watcher.polling && !sleepContext(ctx, watcher.opts.PollInterval) {
+			return
+		}
+	}
+}
+
+// subscribe delivers logs from a live subscription until it fails. It switches the watcher
+// to polling if the client does not support subscriptions.
+func (watcher *logWatcher) subscribe(ctx context.Context) error {
+	logs := make(chan types.Log)
+
+	query := watcher.query
+	query.FromBlock = nil
+	query.ToBlock = nil
+
+	subscription, err := watcher.token.client.SubscribeFilterLogs(ctx, query, logs)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		watcher.polling = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to logs: %w", err)
+	}
+	defer subscription.Unsubscribe()
+
+	// Catch up with the blocks mined while the subscription was being set up.
+	err = watcher.sync(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case log := <-logs:
+			if !watcher.handle(ctx, log) {
+				return ctx.Err()
+			}
+		case err := <-subscription.Err():
+			return fmt.Errorf("log subscription dropped: %w", err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sync queries the logs from the start of the reorg window up to the latest block, delivering the
+// ones not seen yet and reporting delivered logs that are no longer part of the chain as removed.
+func (watcher *logWatcher) sync(ctx context.Context) error {
+	head, err := watcher.token.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if head < watcher.next {
+		return nil
+	}
+
+	from := watcher.start
+	if watcher.next > watcher.start+watcher.opts.ReorgDepth {
+		from = watcher.next - watcher.opts.ReorgDepth
+	}
+
+	query := watcher.query
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = new(big.Int).SetUint64(head)
+
+	logs, err := watcher.token.client.FilterLogs(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to filter logs: %w", err)
+	}
+
+	canonical := make(map[logKey]struct{}, len(logs))
+	for _, log := range logs {
+		canonical[newLogKey(log)] = struct{}{}
+	}
+
+	var removed []types.Log
+	for key, log := range watcher.delivered {
+		if _, ok := canonical[key]; !ok && log.BlockNumber >= from {
+			log.Removed = true
+			removed = append(removed, log)
+		}
+	}
+
+	// Report removals newest first, the order nodes use for reorged logs.
+	sort.Slice(removed, func(i, j int) bool {
+		if removed[i].BlockNumber != removed[j].BlockNumber {
+			return removed[i].BlockNumber > removed[j].BlockNumber
+		}
+
+		return removed[i].Index > removed[j].Index
+	})
+
+	for _, log := range removed {
+		if !watcher.handle(ctx, log) {
+			return ctx.Err()
+		}
+	}
+
+	for _, log := range logs {
+		if !watcher.handle(ctx, log) {
+			return ctx.Err()
+		}
+	}
+
+	watcher.next = max(watcher.next, head+1)
+	watcher.prune()
+
+	return nil
+}
+
+// handle delivers a log or its removal unless it was already delivered. It returns false if ctx is done.
+func (watcher *logWatcher) handle(ctx context.Context, log types.Log) bool {
+	key := newLogKey(log)
+
+	if log.Removed {
+		if _, ok := watcher.delivered[key]; !ok {
+			return true
+		}
+
+		delete(watcher.delivered, key)
+
+		return watcher.deliver(ctx, log)
+	}
+
+	if _, ok := watcher.delivered[key]; ok {
+		return true
+	}
+
+	watcher.delivered[key] = log
+	if log.BlockNumber > watcher.next {
+		watcher.next = log.BlockNumber
+		watcher.prune()
+	}
+
+	return watcher.deliver(ctx, log)
+}
+
+// prune forgets the delivered logs that fell out of the reorg window.
+func (watcher *logWatcher) prune() {
+	if watcher.next <= watcher.opts.ReorgDepth {
+		return
+	}
+
+	oldest := watcher.next - watcher.opts.ReorgDepth
+	for key, log := range watcher.delivered {
+		if log.BlockNumber < oldest {
+			delete(watcher.delivered, key)
+		}
+	}
+}
+
+// reportError passes a recovered error to the OnError callback, if any.
+func (watcher *logWatcher) reportError(err error) {
+	if watcher.opts.OnError != nil {
+		watcher.opts.OnError(err)
+	}
+}
+
+// newLogKey returns the key identifying the log.
+func newLogKey(log types.Log) logKey {
+	return logKey{
+		blockHash: log.BlockHash,
+		txHash:    log.TxHash,
+		index:     log.Index,
+	}
+}
+
+// sleepContext waits for the delay and reports false if ctx was done first.
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}